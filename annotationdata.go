@@ -0,0 +1,280 @@
+package books
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AnnotationDataService defines the behavior required by types that want to implement a new AnnotationData type.
+type AnnotationDataService interface {
+	List(string, string, string, *AnnotationDataListOptions) ([]AnnotationData, *Response, error)
+	ListContext(context.Context, string, string, string, *AnnotationDataListOptions) ([]AnnotationData, *Response, error)
+	Get(string, string, string, string, *AnnotationDataGetOptions) (*AnnotationData, *Response, error)
+	GetContext(context.Context, string, string, string, string, *AnnotationDataGetOptions) (*AnnotationData, *Response, error)
+}
+
+// GoogleAnnotationDataService implements the AnnotationDataService interface.
+type GoogleAnnotationDataService struct {
+	client *Client
+}
+
+// AnnotationData represents a Google Book Annotationdata resource, the payload of a volume annotation.
+// Depending on AnnotationType, Data holds a dictionary entry or a geographic location.
+// https://developers.google.com/books/docs/v1/reference/layers/annotationData#resource
+type AnnotationData struct {
+	Kind           *string                `json:"kind,omitempty"`
+	ID             *string                `json:"id,omitempty"`
+	SelfLink       *string                `json:"selfLink,omitempty"`
+	VolumeID       *string                `json:"volumeId,omitempty"`
+	LayerID        *string                `json:"layerId,omitempty"`
+	AnnotationType *string                `json:"annotationType,omitempty"`
+	EncodedData    *string                `json:"encoded_data,omitempty"`
+	Data           *AnnotationDataPayload `json:"data,omitempty"`
+	Updated        *time.Time             `json:"updated,omitempty"`
+}
+
+// AnnotationDataPayload is the data of an annotation. Dict is set for dictionary annotations and Geo for
+// geographic ones.
+type AnnotationDataPayload struct {
+	Common *AnnotationDataCommon `json:"common,omitempty"`
+	Dict   *DictionaryData       `json:"dict,omitempty"`
+	Geo    *GeoData              `json:"geo,omitempty"`
+}
+
+// AnnotationDataCommon holds the fields shared by every kind of annotation data.
+type AnnotationDataCommon struct {
+	Title           *string `json:"title,omitempty"`
+	Lang            *string `json:"lang,omitempty"`
+	PreviewImageURL *string `json:"previewImageUrl,omitempty"`
+	Snippet         *string `json:"snippet,omitempty"`
+	SnippetURL      *string `json:"snippetUrl,omitempty"`
+}
+
+// DictionaryData is the dictionary entry of a word of a volume.
+type DictionaryData struct {
+	Source *DictionarySource `json:"source,omitempty"`
+	Words  []DictionaryWord  `json:"words,omitempty"`
+}
+
+// DictionarySource is the attribution of a dictionary entry or of a part of it.
+type DictionarySource struct {
+	Attribution *string `json:"attribution,omitempty"`
+	URL         *string `json:"url,omitempty"`
+}
+
+// DictionaryText is a text of a dictionary entry, such as an example or a synonym, with its source.
+type DictionaryText struct {
+	Source *DictionarySource `json:"source,omitempty"`
+	Text   *string           `json:"text,omitempty"`
+}
+
+// DictionaryWord is a word of a dictionary entry.
+type DictionaryWord struct {
+	Derivatives []DictionaryText  `json:"derivatives,omitempty"`
+	Examples    []DictionaryText  `json:"examples,omitempty"`
+	Senses      []DictionarySense `json:"senses,omitempty"`
+	Source      *DictionarySource `json:"source,omitempty"`
+}
+
+// DictionarySense is one of the meanings of a word.
+type DictionarySense struct {
+	Conjugations     []DictionaryConjugation `json:"conjugations,omitempty"`
+	Definitions      []DictionaryDefinition  `json:"definitions,omitempty"`
+	PartOfSpeech     *string                 `json:"partOfSpeech,omitempty"`
+	Pronunciation    *string                 `json:"pronunciation,omitempty"`
+	PronunciationURL *string                 `json:"pronunciationUrl,omitempty"`
+	Source           *DictionarySource       `json:"source,omitempty"`
+	Syllabification  *string                 `json:"syllabification,omitempty"`
+	Synonyms         []DictionaryText        `json:"synonyms,omitempty"`
+}
+
+// DictionaryConjugation is a conjugated form of a word.
+type DictionaryConjugation struct {
+	Type  *string `json:"type,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
+// DictionaryDefinition is a definition of a sense, with its examples.
+type DictionaryDefinition struct {
+	Definition *string          `json:"definition,omitempty"`
+	Examples   []DictionaryText `json:"examples,omitempty"`
+}
+
+// GeoData is a place mentioned in a volume.
+type GeoData struct {
+	Boundary    []string     `json:"boundary,omitempty"`
+	CachePolicy *string      `json:"cachePolicy,omitempty"`
+	CountryCode *string      `json:"countryCode,omitempty"`
+	Latitude    *float64     `json:"latitude,omitempty"`
+	Longitude   *float64     `json:"longitude,omitempty"`
+	MapType     *string      `json:"mapType,omitempty"`
+	Viewport    *GeoViewport `json:"viewport,omitempty"`
+	Zoom        *int         `json:"zoom,omitempty"`
+}
+
+// GeoViewport is the map area to show for a place, between its south west (Lo) and north east (Hi) corners.
+type GeoViewport struct {
+	Hi *GeoPoint `json:"hi,omitempty"`
+	Lo *GeoPoint `json:"lo,omitempty"`
+}
+
+// GeoPoint is a location on the map.
+type GeoPoint struct {
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+type annotationDataRoot struct {
+	Kind          *string          `json:"kind,omitempty"`
+	TotalItems    *int             `json:"totalItems,omitempty"`
+	NextPageToken *string          `json:"nextPageToken,omitempty"`
+	Data          []AnnotationData `json:"items,omitempty"`
+}
+
+func (r *annotationDataRoot) itemCount() int { return len(r.Data) }
+
+// AnnotationDataListOptions specifies the optional parameters for books.layers.annotationData.list.
+// W, H and Scale size the images of the data, such as maps.
+type AnnotationDataListOptions struct {
+	AnnotationDataIDs []string  `url:"annotationDataId,omitempty"`
+	UpdatedMin        time.Time `url:"updatedMin,omitempty"`
+	UpdatedMax        time.Time `url:"updatedMax,omitempty"`
+	Locale            string    `url:"locale,omitempty"`
+	Scale             int       `url:"scale,omitempty"`
+	W                 int       `url:"w,omitempty"`
+	H                 int       `url:"h,omitempty"`
+	MaxResults        int       `url:"maxResults,omitempty"`
+	PageToken         string    `url:"pageToken,omitempty"`
+	Source            string    `url:"source,omitempty"`
+}
+
+// AnnotationDataGetOptions specifies the optional parameters for books.layers.annotationData.get.
+// AllowWebDefinitions allows dictionary definitions from the web when the dictionary has none.
+type AnnotationDataGetOptions struct {
+	AllowWebDefinitions bool   `url:"allowWebDefinitions,omitempty"`
+	Locale              string `url:"locale,omitempty"`
+	Scale               int    `url:"scale,omitempty"`
+	W                   int    `url:"w,omitempty"`
+	H                   int    `url:"h,omitempty"`
+	Source              string `url:"source,omitempty"`
+}
+
+// annotationDataListParams specifies the parameters sent to books.layers.annotationData.list.
+type annotationDataListParams struct {
+	ContentVersion string `url:"contentVersion"`
+	AnnotationDataListOptions
+}
+
+// annotationDataGetParams specifies the parameters sent to books.layers.annotationData.get.
+type annotationDataGetParams struct {
+	ContentVersion string `url:"contentVersion"`
+	AnnotationDataGetOptions
+}
+
+// List will call the books.layers.annotationData.list API to list the annotation data of a layer, for the version
+// contentVersion of the volume content.
+// https://www.googleapis.com/books/v1/volumes/{volumeId}/layers/{layerId}/data
+func (l *GoogleAnnotationDataService) List(volumeID string, layerID string, contentVersion string, opt *AnnotationDataListOptions) ([]AnnotationData, *Response, error) {
+	return l.ListContext(context.Background(), volumeID, layerID, contentVersion, opt)
+}
+
+// ListContext is like List but takes a context.
+func (l *GoogleAnnotationDataService) ListContext(ctx context.Context, volumeID string, layerID string, contentVersion string, opt *AnnotationDataListOptions) ([]AnnotationData, *Response, error) {
+	if volumeID == "" {
+		return nil, nil, errors.New("volumeID is a required field")
+	}
+	if layerID == "" {
+		return nil, nil, errors.New("layerID is a required field")
+	}
+	if contentVersion == "" {
+		return nil, nil, errors.New("contentVersion is a required field")
+	}
+
+	op := Operation{Service: "Layers", Name: "books.layers.annotationData.list", VolumeID: volumeID}
+	if opt != nil {
+		op.PageToken = opt.PageToken
+	}
+	ctx = withOperation(ctx, op)
+
+	params := &annotationDataListParams{ContentVersion: contentVersion}
+	if opt != nil {
+		params.AnnotationDataListOptions = *opt
+	}
+
+	url := fmt.Sprintf("volumes/%s/layers/%s/data", volumeID, layerID)
+	url, err := addOptions(url, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := l.client.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(annotationDataRoot)
+	resp, err := l.client.Do(req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if n := root.NextPageToken; n != nil {
+		resp.NextPageToken = *n
+	}
+	if t := root.TotalItems; t != nil {
+		resp.TotalItems = *t
+	}
+
+	return root.Data, resp, err
+}
+
+// Get will call the books.layers.annotationData.get API to get the data of a single annotation, for the version
+// contentVersion of the volume content.
+// https://www.googleapis.com/books/v1/volumes/{volumeId}/layers/{layerId}/data/{annotationDataId}
+func (l *GoogleAnnotationDataService) Get(volumeID string, layerID string, annotationDataID string, contentVersion string, opt *AnnotationDataGetOptions) (*AnnotationData, *Response, error) {
+	return l.GetContext(context.Background(), volumeID, layerID, annotationDataID, contentVersion, opt)
+}
+
+// GetContext is like Get but takes a context.
+func (l *GoogleAnnotationDataService) GetContext(ctx context.Context, volumeID string, layerID string, annotationDataID string, contentVersion string, opt *AnnotationDataGetOptions) (*AnnotationData, *Response, error) {
+	if volumeID == "" {
+		return nil, nil, errors.New("volumeID is a required field")
+	}
+	if layerID == "" {
+		return nil, nil, errors.New("layerID is a required field")
+	}
+	if annotationDataID == "" {
+		return nil, nil, errors.New("annotationDataID is a required field")
+	}
+	if contentVersion == "" {
+		return nil, nil, errors.New("contentVersion is a required field")
+	}
+
+	ctx = withOperation(ctx, Operation{Service: "Layers", Name: "books.layers.annotationData.get", VolumeID: volumeID, AnnotationID: annotationDataID})
+
+	params := &annotationDataGetParams{ContentVersion: contentVersion}
+	if opt != nil {
+		params.AnnotationDataGetOptions = *opt
+	}
+
+	url := fmt.Sprintf("volumes/%s/layers/%s/data/%s", volumeID, layerID, annotationDataID)
+	url, err := addOptions(url, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := l.client.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := new(AnnotationData)
+	resp, err := l.client.Do(req, data)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return data, resp, err
+}
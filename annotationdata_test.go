@@ -0,0 +1,140 @@
+package books
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAnnotationDataList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/volumes/VN2jCgAAAEAJ/layers/geo/data", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.Query()["annotationDataId"], []string{"geo-1", "geo-2"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("annotationDataId = %v, expected %v", got, want)
+		}
+		if got, want := r.URL.Query().Get("updatedMin"), "2016-01-01T00:00:00Z"; got != want {
+			t.Errorf("updatedMin = %q, expected %q", got, want)
+		}
+		for k, want := range map[string]string{"contentVersion": "full-1.0.0", "locale": "en", "scale": "2", "w": "320", "h": "240"} {
+			if got := r.URL.Query().Get(k); got != want {
+				t.Errorf("%s = %q, expected %q", k, got, want)
+			}
+		}
+		fmt.Fprint(w, `{"totalItems":1,"items":[{"id":"geo-1","data":{"geo":{"zoom":12}}}]}`)
+	})
+
+	opts := &AnnotationDataListOptions{
+		AnnotationDataIDs: []string{"geo-1", "geo-2"},
+		UpdatedMin:        time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC),
+		Locale:            "en",
+		Scale:             2,
+		W:                 320,
+		H:                 240,
+	}
+	data, resp, err := client.Layers.AnnotationData().List("VN2jCgAAAEAJ", "geo", "full-1.0.0", opts)
+	if err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+
+	expected := []AnnotationData{{ID: String("geo-1"), Data: &AnnotationDataPayload{Geo: &GeoData{Zoom: Int(12)}}}}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("List() returned %+v, expected %+v", data, expected)
+	}
+	if resp.TotalItems != 1 {
+		t.Errorf("TotalItems = %d, expected 1", resp.TotalItems)
+	}
+}
+
+func TestAnnotationDataList_golden(t *testing.T) {
+	setup()
+	defer teardown()
+
+	golden := loadFixture(t, "annotationdata_list.json")
+	mux.HandleFunc("/volumes/VN2jCgAAAEAJ/layers/dictionary/data", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(golden)
+	})
+
+	data, resp, err := client.Layers.AnnotationData().List("VN2jCgAAAEAJ", "dictionary", "full-1.0.0", nil)
+	if err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+
+	var root struct {
+		Items json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(golden, &root); err != nil {
+		t.Fatalf("json.Unmarshal(): %v", err)
+	}
+	testJSONRoundTrip(t, root.Items, data)
+
+	if got, expected := resp.NextPageToken, "CgVnZW8tMQ"; got != expected {
+		t.Errorf("NextPageToken = %q, expected %q", got, expected)
+	}
+
+	dict := data[0].Data
+	if dict.Geo != nil || dict.Dict == nil {
+		t.Fatalf("dictionary data = %+v, expected only Dict", dict)
+	}
+	sense := dict.Dict.Words[0].Senses[0]
+	if got, expected := *sense.Definitions[0].Definition, "Existing, happening, or done at the same time."; got != expected {
+		t.Errorf("Definition = %q, expected %q", got, expected)
+	}
+
+	geo := data[1].Data
+	if geo.Dict != nil || geo.Geo == nil {
+		t.Fatalf("geo data = %+v, expected only Geo", geo)
+	}
+	if got, expected := *geo.Geo.Viewport.Hi.Latitude, 37.4694; got != expected {
+		t.Errorf("Viewport.Hi.Latitude = %v, expected %v", got, expected)
+	}
+}
+
+func TestAnnotationDataGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/volumes/VN2jCgAAAEAJ/layers/dictionary/data/dict-goroutine", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"contentVersion": "full-1.0.0", "allowWebDefinitions": "true", "locale": "en"})
+		fmt.Fprint(w, `{"id":"dict-goroutine","annotationType":"dictionary","data":{"common":{"title":"concurrent"}}}`)
+	})
+
+	opts := &AnnotationDataGetOptions{AllowWebDefinitions: true, Locale: "en"}
+	data, _, err := client.Layers.AnnotationData().Get("VN2jCgAAAEAJ", "dictionary", "dict-goroutine", "full-1.0.0", opts)
+	if err != nil {
+		t.Fatalf("Get() returned an error: %v", err)
+	}
+
+	expected := &AnnotationData{
+		ID:             String("dict-goroutine"),
+		AnnotationType: String("dictionary"),
+		Data:           &AnnotationDataPayload{Common: &AnnotationDataCommon{Title: String("concurrent")}},
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("Get() returned %+v, expected %+v", data, expected)
+	}
+}
+
+func TestAnnotationData_requiredFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	if _, _, err := client.Layers.AnnotationData().List("VN2jCgAAAEAJ", "", "full-1.0.0", nil); err == nil {
+		t.Error("List() without layerID returned no error")
+	}
+	if _, _, err := client.Layers.AnnotationData().List("VN2jCgAAAEAJ", "geo", "", nil); err == nil {
+		t.Error("List() without contentVersion returned no error")
+	}
+	if _, _, err := client.Layers.AnnotationData().Get("VN2jCgAAAEAJ", "geo", "", "full-1.0.0", nil); err == nil {
+		t.Error("Get() without annotationDataID returned no error")
+	}
+	if _, _, err := client.Layers.AnnotationData().Get("VN2jCgAAAEAJ", "geo", "geo-1", "", nil); err == nil {
+		t.Error("Get() without contentVersion returned no error")
+	}
+}
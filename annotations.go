@@ -1,8 +1,24 @@
 package books
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
 // AnnotationsService defines the behavior required by types that want to implement a new Annotation type.
 type AnnotationsService interface {
 	List(*AnnotationsListOptions) ([]Annotation, *Response, error)
+	ListContext(context.Context, *AnnotationsListOptions) ([]Annotation, *Response, error)
+	Insert(*Annotation, *AnnotationsWriteOptions) (*Annotation, *Response, error)
+	InsertContext(context.Context, *Annotation, *AnnotationsWriteOptions) (*Annotation, *Response, error)
+	Update(string, *Annotation, *AnnotationsWriteOptions) (*Annotation, *Response, error)
+	UpdateContext(context.Context, string, *Annotation, *AnnotationsWriteOptions) (*Annotation, *Response, error)
+	Delete(string, *AnnotationsWriteOptions) (*Response, error)
+	DeleteContext(context.Context, string, *AnnotationsWriteOptions) (*Response, error)
+	Summary([]string, string) (*AnnotationsSummary, *Response, error)
+	SummaryContext(context.Context, []string, string) (*AnnotationsSummary, *Response, error)
 }
 
 // GoogleAnnotationsService implements the AnnotationService interface.
@@ -11,21 +27,77 @@ type GoogleAnnotationsService struct {
 }
 
 // Annotation represents a Google Book Annotation resource.
+// https://developers.google.com/books/docs/v1/reference/mylibrary/annotations#resource
 type Annotation struct {
-	SelectedText *string  `json:"selectedText,omitempty"`
-	VolumeID     *string  `json:"VolumeID,omitempty"`
-	ID           *string  `json:"id,omitempty"`
-	LayerID      *string  `json:"layerId,omitempty"`
-	PageIds      []string `json:"pageIds,omitempty"`
+	Kind                 *string                `json:"kind,omitempty"`
+	ID                   *string                `json:"id,omitempty"`
+	SelfLink             *string                `json:"selfLink,omitempty"`
+	Created              *time.Time             `json:"created,omitempty"`
+	Updated              *time.Time             `json:"updated,omitempty"`
+	Deleted              *bool                  `json:"deleted,omitempty"`
+	VolumeID             *string                `json:"volumeId,omitempty"`
+	LayerID              *string                `json:"layerId,omitempty"`
+	SelectedText         *string                `json:"selectedText,omitempty"`
+	BeforeSelectedText   *string                `json:"beforeSelectedText,omitempty"`
+	AfterSelectedText    *string                `json:"afterSelectedText,omitempty"`
+	HighlightStyle       *string                `json:"highlightStyle,omitempty"`
+	Data                 *string                `json:"data,omitempty"`
+	PageIds              []string               `json:"pageIds,omitempty"`
+	CurrentVersionRanges *AnnotationRanges      `json:"currentVersionRanges,omitempty"`
+	ClientVersionRanges  *AnnotationRanges      `json:"clientVersionRanges,omitempty"`
+	LayerSummary         *AnnotationLayerLimits `json:"layerSummary,omitempty"`
+}
+
+// AnnotationRanges holds the selection of an annotation in each of the position formats, for the given content version.
+type AnnotationRanges struct {
+	ContentVersion *string          `json:"contentVersion,omitempty"`
+	CfiRange       *AnnotationRange `json:"cfiRange,omitempty"`
+	GbImageRange   *AnnotationRange `json:"gbImageRange,omitempty"`
+	GbTextRange    *AnnotationRange `json:"gbTextRange,omitempty"`
+	ImageCfiRange  *AnnotationRange `json:"imageCfiRange,omitempty"`
+}
+
+// AnnotationRange is a start and end position within a volume, each with an offset into that position.
+type AnnotationRange struct {
+	StartPosition *string `json:"startPosition,omitempty"`
+	StartOffset   *string `json:"startOffset,omitempty"`
+	EndPosition   *string `json:"endPosition,omitempty"`
+	EndOffset     *string `json:"endOffset,omitempty"`
+}
+
+// AnnotationLayerLimits reports the character allowance of the layer the annotation belongs to.
+type AnnotationLayerLimits struct {
+	AllowedCharacterCount   *int    `json:"allowedCharacterCount,omitempty"`
+	LimitType               *string `json:"limitType,omitempty"`
+	RemainingCharacterCount *int    `json:"remainingCharacterCount,omitempty"`
+}
+
+// AnnotationsSummary represents the per-layer annotation allowance of a volume.
+// https://developers.google.com/books/docs/v1/reference/mylibrary/annotations/summary#response
+type AnnotationsSummary struct {
+	Kind   *string                   `json:"kind,omitempty"`
+	Layers []AnnotationsLayerSummary `json:"layers,omitempty"`
+}
+
+// AnnotationsLayerSummary reports the characters allowed and remaining for a single layer.
+type AnnotationsLayerSummary struct {
+	LayerID                 *string    `json:"layerId,omitempty"`
+	AllowedCharacterCount   *int       `json:"allowedCharacterCount,omitempty"`
+	LimitType               *string    `json:"limitType,omitempty"`
+	RemainingCharacterCount *int       `json:"remainingCharacterCount,omitempty"`
+	Updated                 *time.Time `json:"updated,omitempty"`
 }
 
 // annotationRoot represents a response from Google Books API.
 type annotationRoot struct {
+	Kind          *string      `json:"kind,omitempty"`
 	TotalItems    *int         `json:"totalItems,omitempty"`
 	NextPageToken *string      `json:"nextPageToken,omitempty"`
 	Annotations   []Annotation `json:"items,omitempty"`
 }
 
+func (r *annotationRoot) itemCount() int { return len(r.Annotations) }
+
 // AnnotationsListOptions specifies the optional parameters needed for AnnotationsListOptions.
 type AnnotationsListOptions struct {
 	ContentVersion string `url:"contentVersion,omitempty"`
@@ -37,16 +109,41 @@ type AnnotationsListOptions struct {
 	PageToken      string `url:"pageToken,omitempty"`
 }
 
+// AnnotationsWriteOptions specifies the optional parameters for the annotation insert, update and delete API calls.
+// Country and ShowOnlySummaryInResponse are only used by books.mylibrary.annotations.insert.
+type AnnotationsWriteOptions struct {
+	Country                   string `url:"country,omitempty"`
+	ShowOnlySummaryInResponse bool   `url:"showOnlySummaryInResponse,omitempty"`
+	Source                    string `url:"source,omitempty"`
+}
+
+// annotationsSummaryOptions specifies the parameters needed for books.mylibrary.annotations.summary.
+type annotationsSummaryOptions struct {
+	LayerIDs []string `url:"layerIds"`
+	VolumeID string   `url:"volumeId"`
+}
+
 // List will call Annotation service with opts param.
 // books.mylibrary.annotations.list
 func (u *GoogleAnnotationsService) List(opt *AnnotationsListOptions) ([]Annotation, *Response, error) {
+	return u.ListContext(context.Background(), opt)
+}
+
+// ListContext is like List but takes a context.
+func (u *GoogleAnnotationsService) ListContext(ctx context.Context, opt *AnnotationsListOptions) ([]Annotation, *Response, error) {
+	op := Operation{Service: "Annotations", Name: "books.mylibrary.annotations.list"}
+	if opt != nil {
+		op.VolumeID, op.PageToken = opt.VolumeID, opt.PageToken
+	}
+	ctx = withOperation(ctx, op)
+
 	url := "mylibrary/annotations"
 	url, err := addOptions(url, opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := u.client.NewRequest("GET", url, nil)
+	req, err := u.client.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -60,6 +157,246 @@ func (u *GoogleAnnotationsService) List(opt *AnnotationsListOptions) ([]Annotati
 	if n := root.NextPageToken; n != nil {
 		resp.NextPageToken = *n
 	}
+	if t := root.TotalItems; t != nil {
+		resp.TotalItems = *t
+	}
 
 	return root.Annotations, resp, err
 }
+
+// Insert will call the books.mylibrary.annotations.insert API to create a new annotation.
+// https://www.googleapis.com/books/v1/mylibrary/annotations
+func (u *GoogleAnnotationsService) Insert(annotation *Annotation, opt *AnnotationsWriteOptions) (*Annotation, *Response, error) {
+	return u.InsertContext(context.Background(), annotation, opt)
+}
+
+// InsertContext is like Insert but takes a context.
+func (u *GoogleAnnotationsService) InsertContext(ctx context.Context, annotation *Annotation, opt *AnnotationsWriteOptions) (*Annotation, *Response, error) {
+	if annotation == nil {
+		return nil, nil, errors.New("annotation is a required field")
+	}
+
+	op := Operation{Service: "Annotations", Name: "books.mylibrary.annotations.insert"}
+	if annotation.VolumeID != nil {
+		op.VolumeID = *annotation.VolumeID
+	}
+	ctx = withOperation(ctx, op)
+
+	url, err := addOptions("mylibrary/annotations", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := u.client.NewRequestWithContext(ctx, "POST", url, annotation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created := new(Annotation)
+	resp, err := u.client.Do(req, created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return created, resp, err
+}
+
+// Update will call the books.mylibrary.annotations.update API to replace an existing annotation.
+// https://www.googleapis.com/books/v1/mylibrary/annotations/{annotationId}
+func (u *GoogleAnnotationsService) Update(annotationID string, annotation *Annotation, opt *AnnotationsWriteOptions) (*Annotation, *Response, error) {
+	return u.UpdateContext(context.Background(), annotationID, annotation, opt)
+}
+
+// UpdateContext is like Update but takes a context.
+func (u *GoogleAnnotationsService) UpdateContext(ctx context.Context, annotationID string, annotation *Annotation, opt *AnnotationsWriteOptions) (*Annotation, *Response, error) {
+	if annotationID == "" {
+		return nil, nil, errors.New("annotationID is a required field")
+	}
+	if annotation == nil {
+		return nil, nil, errors.New("annotation is a required field")
+	}
+
+	op := Operation{Service: "Annotations", Name: "books.mylibrary.annotations.update", AnnotationID: annotationID}
+	if annotation.VolumeID != nil {
+		op.VolumeID = *annotation.VolumeID
+	}
+	ctx = withOperation(ctx, op)
+
+	url := fmt.Sprintf("mylibrary/annotations/%s", annotationID)
+	url, err := addOptions(url, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := u.client.NewRequestWithContext(ctx, "PUT", url, annotation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated := new(Annotation)
+	resp, err := u.client.Do(req, updated)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updated, resp, err
+}
+
+// Delete will call the books.mylibrary.annotations.delete API to remove an annotation.
+// https://www.googleapis.com/books/v1/mylibrary/annotations/{annotationId}
+func (u *GoogleAnnotationsService) Delete(annotationID string, opt *AnnotationsWriteOptions) (*Response, error) {
+	return u.DeleteContext(context.Background(), annotationID, opt)
+}
+
+// DeleteContext is like Delete but takes a context.
+func (u *GoogleAnnotationsService) DeleteContext(ctx context.Context, annotationID string, opt *AnnotationsWriteOptions) (*Response, error) {
+	if annotationID == "" {
+		return nil, errors.New("annotationID is a required field")
+	}
+
+	ctx = withOperation(ctx, Operation{Service: "Annotations", Name: "books.mylibrary.annotations.delete", AnnotationID: annotationID})
+
+	url := fmt.Sprintf("mylibrary/annotations/%s", annotationID)
+	url, err := addOptions(url, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := u.client.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.client.Do(req, nil)
+}
+
+// Summary will call the books.mylibrary.annotations.summary API to get the annotation allowance of the
+// given layers of a volume.
+// https://www.googleapis.com/books/v1/mylibrary/annotations/summary
+func (u *GoogleAnnotationsService) Summary(layerIDs []string, volumeID string) (*AnnotationsSummary, *Response, error) {
+	return u.SummaryContext(context.Background(), layerIDs, volumeID)
+}
+
+// SummaryContext is like Summary but takes a context.
+func (u *GoogleAnnotationsService) SummaryContext(ctx context.Context, layerIDs []string, volumeID string) (*AnnotationsSummary, *Response, error) {
+	if len(layerIDs) == 0 {
+		return nil, nil, errors.New("layerIDs is a required field")
+	}
+	if volumeID == "" {
+		return nil, nil, errors.New("volumeID is a required field")
+	}
+
+	ctx = withOperation(ctx, Operation{Service: "Annotations", Name: "books.mylibrary.annotations.summary", VolumeID: volumeID})
+
+	opt := &annotationsSummaryOptions{LayerIDs: layerIDs, VolumeID: volumeID}
+	url, err := addOptions("mylibrary/annotations/summary", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := u.client.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	summary := new(AnnotationsSummary)
+	resp, err := u.client.Do(req, summary)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return summary, resp, err
+}
+
+// AnnotationsIterator lazily fetches annotations page by page, following NextPageToken.
+//
+//	it := books.NewAnnotationsIterator(ctx, client.Annotations, opts, 0)
+//	for it.Next() {
+//		note := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle err
+//	}
+type AnnotationsIterator struct {
+	ctx     context.Context
+	service AnnotationsService
+	opt     AnnotationsListOptions
+	limit   int
+
+	page  []Annotation
+	index int
+	count int
+	value Annotation
+	resp  *Response
+	last  bool
+	err   error
+}
+
+// NewAnnotationsIterator returns an iterator over the annotations listed by s with opt. A limit greater than zero
+// stops the iteration after that many annotations. opt is copied, so it is not modified by the iterator.
+func NewAnnotationsIterator(ctx context.Context, s AnnotationsService, opt *AnnotationsListOptions, limit int) *AnnotationsIterator {
+	it := &AnnotationsIterator{ctx: ctx, service: s, limit: limit}
+	if opt != nil {
+		it.opt = *opt
+	}
+	return it
+}
+
+// Next advances the iterator to the next annotation, fetching the next page when needed. It returns false when
+// the iteration is over or an error occurred, which is then reported by Err.
+func (it *AnnotationsIterator) Next() bool {
+	if it.err != nil || (it.limit > 0 && it.count >= it.limit) {
+		return false
+	}
+
+	for it.index >= len(it.page) {
+		if it.last {
+			return false
+		}
+		if err := it.fetch(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	it.value = it.page[it.index]
+	it.index++
+	it.count++
+	return true
+}
+
+// fetch gets the next page of annotations.
+func (it *AnnotationsIterator) fetch() error {
+	if it.limit > 0 {
+		if remaining := it.limit - it.count; it.opt.MaxResults == 0 || remaining < it.opt.MaxResults {
+			it.opt.MaxResults = remaining
+		}
+	}
+	if it.opt.MaxResults > maxPageSize {
+		it.opt.MaxResults = maxPageSize
+	}
+
+	page, resp, err := it.service.ListContext(it.ctx, &it.opt)
+	it.resp = resp
+	if err != nil {
+		return err
+	}
+
+	it.page, it.index = page, 0
+
+	// Stop when there is no next page, or the API hands back the token it was given.
+	if resp.NextPageToken == "" || resp.NextPageToken == it.opt.PageToken {
+		it.last = true
+	}
+	it.opt.PageToken = resp.NextPageToken
+	return nil
+}
+
+// Value returns the current annotation.
+func (it *AnnotationsIterator) Value() Annotation { return it.value }
+
+// Response returns the response of the page the current annotation belongs to.
+func (it *AnnotationsIterator) Response() *Response { return it.resp }
+
+// Err returns the error that stopped the iteration, if any.
+func (it *AnnotationsIterator) Err() error { return it.err }
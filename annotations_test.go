@@ -1,10 +1,17 @@
 package books
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestAnnotations_List(t *testing.T) {
@@ -51,3 +58,345 @@ func TestAnnotationsList_badBody(t *testing.T) {
 		t.Errorf("List() Expected Status code got %v, want %v", got, want)
 	}
 }
+
+func TestAnnotationsList_golden(t *testing.T) {
+	setup()
+	defer teardown()
+
+	golden := loadFixture(t, "annotations_list.json")
+	mux.HandleFunc("/mylibrary/annotations", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(golden)
+	})
+
+	list, resp, err := client.Annotations.List(nil)
+	if err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+
+	var root struct {
+		Items json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(golden, &root); err != nil {
+		t.Fatalf("json.Unmarshal(): %v", err)
+	}
+	testJSONRoundTrip(t, root.Items, list)
+
+	if got, expected := resp.NextPageToken, "CgwI-Z3nuQUQgMfHtAE"; got != expected {
+		t.Errorf("NextPageToken = %q, expected %q", got, expected)
+	}
+
+	note := list[0]
+	created := time.Date(2016, 5, 13, 21, 39, 50, 456000000, time.UTC)
+	if !note.Created.Equal(created) {
+		t.Errorf("Created = %v, expected %v", note.Created, created)
+	}
+	if !note.Updated.After(*note.Created) {
+		t.Errorf("Updated = %v, expected after %v", note.Updated, note.Created)
+	}
+	expectedRange := &AnnotationRange{
+		StartPosition: String("GBS.PA23.w.1.0.0"),
+		StartOffset:   String("10"),
+		EndPosition:   String("GBS.PA23.w.1.0.0"),
+		EndOffset:     String("51"),
+	}
+	if !reflect.DeepEqual(note.CurrentVersionRanges.GbTextRange, expectedRange) {
+		t.Errorf("CurrentVersionRanges.GbTextRange = %+v, expected %+v", note.CurrentVersionRanges.GbTextRange, expectedRange)
+	}
+	if got, expected := *note.LayerSummary.RemainingCharacterCount, 79239; got != expected {
+		t.Errorf("LayerSummary.RemainingCharacterCount = %v, expected %v", got, expected)
+	}
+	if !*list[1].Deleted {
+		t.Errorf("Deleted = false, expected true")
+	}
+}
+
+func TestAnnotationsInsert(t *testing.T) {
+	setup()
+	defer teardown()
+
+	input := &Annotation{VolumeID: String("VN2jCgAAAEAJ"), LayerID: String("notes"), SelectedText: String("Go")}
+
+	mux.HandleFunc("/mylibrary/annotations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"country": "US", "showOnlySummaryInResponse": "true", "source": "ge-web-app1"})
+
+		v := new(Annotation)
+		if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+			t.Fatalf("decode request body: %v", err)
+		}
+		if !reflect.DeepEqual(v, input) {
+			t.Errorf("Request body = %+v, expected %+v", v, input)
+		}
+		fmt.Fprint(w, `{"id":"AO7b3V1","volumeId":"VN2jCgAAAEAJ","layerId":"notes","selectedText":"Go"}`)
+	})
+
+	opts := &AnnotationsWriteOptions{Country: "US", ShowOnlySummaryInResponse: true, Source: "ge-web-app1"}
+	annotation, _, err := client.Annotations.Insert(input, opts)
+	if err != nil {
+		t.Errorf("Insert() returned an error: %v", err)
+	}
+
+	expected := &Annotation{ID: String("AO7b3V1"), VolumeID: String("VN2jCgAAAEAJ"), LayerID: String("notes"), SelectedText: String("Go")}
+	if !reflect.DeepEqual(annotation, expected) {
+		t.Errorf("Insert() returned %+v, expected %+v", annotation, expected)
+	}
+}
+
+func TestAnnotationsUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	input := &Annotation{ID: String("AO7b3V1"), Data: String("updated note")}
+
+	mux.HandleFunc("/mylibrary/annotations/AO7b3V1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testFormValues(t, r, values{"source": "ge-web-app1"})
+
+		v := new(Annotation)
+		if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+			t.Fatalf("decode request body: %v", err)
+		}
+		if !reflect.DeepEqual(v, input) {
+			t.Errorf("Request body = %+v, expected %+v", v, input)
+		}
+		fmt.Fprint(w, `{"id":"AO7b3V1","data":"updated note"}`)
+	})
+
+	annotation, _, err := client.Annotations.Update("AO7b3V1", input, &AnnotationsWriteOptions{Source: "ge-web-app1"})
+	if err != nil {
+		t.Errorf("Update() returned an error: %v", err)
+	}
+
+	if !reflect.DeepEqual(annotation, input) {
+		t.Errorf("Update() returned %+v, expected %+v", annotation, input)
+	}
+}
+
+func TestAnnotationsDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/mylibrary/annotations/AO7b3V1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := client.Annotations.Delete("AO7b3V1", nil)
+	if err != nil {
+		t.Errorf("Delete() returned an error: %v", err)
+	}
+
+	if got, want := resp.StatusCode, http.StatusNoContent; got != want {
+		t.Errorf("Delete() Expected Status code got %v, want %v", got, want)
+	}
+}
+
+func TestAnnotationsWrite_requiredFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	if _, _, err := client.Annotations.Insert(nil, nil); err == nil {
+		t.Error("Insert() Expected annotation error.")
+	}
+	if _, _, err := client.Annotations.Update("", &Annotation{}, nil); err == nil {
+		t.Error("Update() Expected annotationID error.")
+	}
+	if _, _, err := client.Annotations.Update("AO7b3V1", nil, nil); err == nil {
+		t.Error("Update() Expected annotation error.")
+	}
+	if _, err := client.Annotations.Delete("", nil); err == nil {
+		t.Error("Delete() Expected annotationID error.")
+	}
+}
+
+func TestAnnotationsSummary(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/mylibrary/annotations/summary", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if got, expected := r.URL.Query()["layerIds"], []string{"notes", "bookmarks"}; !reflect.DeepEqual(got, expected) {
+			t.Errorf("layerIds = %v, expected %v", got, expected)
+		}
+		if got, expected := r.URL.Query().Get("volumeId"), "VN2jCgAAAEAJ"; got != expected {
+			t.Errorf("volumeId = %v, expected %v", got, expected)
+		}
+		fmt.Fprint(w, `{"kind":"books#annotationsSummary","layers":[{"layerId":"notes","allowedCharacterCount":79680,"limitType":"CONCURRENT","remainingCharacterCount":79239,"updated":"2016-05-14T08:02:11.123Z"}]}`)
+	})
+
+	summary, _, err := client.Annotations.Summary([]string{"notes", "bookmarks"}, "VN2jCgAAAEAJ")
+	if err != nil {
+		t.Fatalf("Summary() returned an error: %v", err)
+	}
+
+	updated := time.Date(2016, 5, 14, 8, 2, 11, 123000000, time.UTC)
+	expected := &AnnotationsSummary{
+		Kind: String("books#annotationsSummary"),
+		Layers: []AnnotationsLayerSummary{{
+			LayerID:                 String("notes"),
+			AllowedCharacterCount:   Int(79680),
+			LimitType:               String("CONCURRENT"),
+			RemainingCharacterCount: Int(79239),
+			Updated:                 &updated,
+		}},
+	}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("Summary() returned %+v, expected %+v", summary, expected)
+	}
+}
+
+func TestAnnotationsSummary_requiredFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	if _, _, err := client.Annotations.Summary(nil, "VN2jCgAAAEAJ"); err == nil {
+		t.Error("Summary() Expected layerIDs error.")
+	}
+	if _, _, err := client.Annotations.Summary([]string{"notes"}, ""); err == nil {
+		t.Error("Summary() Expected volumeID error.")
+	}
+}
+
+func TestAnnotationsListContext_canceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := client.Annotations.ListContext(ctx, &AnnotationsListOptions{VolumeID: "VN2jCgAAAEAJ"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ListContext() error = %v, expected %v", err, context.Canceled)
+	}
+}
+
+func TestAnnotationsIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pages := map[string]string{
+		"":   `{"totalItems":5,"nextPageToken":"p2","items":[{"id":"1"},{"id":"2"}]}`,
+		"p2": `{"totalItems":5,"nextPageToken":"p3","items":[{"id":"3"},{"id":"4"}]}`,
+		"p3": `{"totalItems":5,"items":[{"id":"5"}]}`,
+	}
+	mux.HandleFunc("/mylibrary/annotations", func(w http.ResponseWriter, r *http.Request) {
+		if got, expected := r.URL.Query().Get("volumeId"), "VN2jCgAAAEAJ"; got != expected {
+			t.Errorf("volumeId = %q, expected %q", got, expected)
+		}
+		fmt.Fprint(w, pages[r.URL.Query().Get("pageToken")])
+	})
+
+	opts := &AnnotationsListOptions{VolumeID: "VN2jCgAAAEAJ", MaxResults: 2}
+	it := NewAnnotationsIterator(context.Background(), client.Annotations, opts, 0)
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, *it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	if expected := []string{"1", "2", "3", "4", "5"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("iterated %v, expected %v", ids, expected)
+	}
+	if got := it.Response().TotalItems; got != 5 {
+		t.Errorf("Response().TotalItems = %v, expected 5", got)
+	}
+	if opts.PageToken != "" {
+		t.Errorf("iterator modified the caller's options: %+v", opts)
+	}
+}
+
+func TestAnnotationsIterator_limit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []url.Values
+	mux.HandleFunc("/mylibrary/annotations", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Query())
+		if r.URL.Query().Get("pageToken") == "" {
+			fmt.Fprint(w, `{"nextPageToken":"p2","items":[{"id":"1"},{"id":"2"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"nextPageToken":"p3","items":[{"id":"3"}]}`)
+	})
+
+	it := NewAnnotationsIterator(context.Background(), client.Annotations, &AnnotationsListOptions{MaxResults: 2}, 3)
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 3 {
+		t.Errorf("iterated %d annotations, expected 3", count)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("sent %d requests, expected 2", len(requests))
+	}
+	if got := requests[1].Get("maxResults"); got != "1" {
+		t.Errorf("last page maxResults = %q, expected 1", got)
+	}
+}
+
+func TestAnnotationsIterator_error(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/mylibrary/annotations", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pageToken") == "" {
+			fmt.Fprint(w, `{"nextPageToken":"p2","items":[{"id":"1"}]}`)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"error":{"code":500,"message":"Backend Error"}}`)
+	})
+
+	it := NewAnnotationsIterator(context.Background(), client.Annotations, nil, 0)
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 1 {
+		t.Errorf("iterated %d annotations, expected 1", count)
+	}
+	if _, ok := it.Err().(*ErrorResponse); !ok {
+		t.Errorf("Err() = %#v, expected *ErrorResponse", it.Err())
+	}
+	if got := it.Response().StatusCode; got != http.StatusInternalServerError {
+		t.Errorf("Response().StatusCode = %v, expected %v", got, http.StatusInternalServerError)
+	}
+	if it.Next() {
+		t.Error("Next() = true after an error")
+	}
+}
+
+func TestAnnotationsIterator_pageSize(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var maxResults []string
+	mux.HandleFunc("/mylibrary/annotations", func(w http.ResponseWriter, r *http.Request) {
+		maxResults = append(maxResults, r.URL.Query().Get("maxResults"))
+		n, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+		items := make([]string, n)
+		for i := range items {
+			items[i] = `{"id":"a"}`
+		}
+		fmt.Fprintf(w, `{"nextPageToken":"p%d","items":[%s]}`, len(maxResults), strings.Join(items, ","))
+	})
+
+	it := NewAnnotationsIterator(context.Background(), client.Annotations, &AnnotationsListOptions{MaxResults: 100}, 50)
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 50 {
+		t.Errorf("iterated %d annotations, expected 50", count)
+	}
+	if expected := []string{"40", "10"}; !reflect.DeepEqual(maxResults, expected) {
+		t.Errorf("maxResults = %v, expected %v", maxResults, expected)
+	}
+}
@@ -0,0 +1,274 @@
+package books
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// MaxBatchSize is the number of calls sent in a single batch request. Larger batches are split.
+const MaxBatchSize = 100
+
+// ErrBatchMultipleRequests is returned to a queued call making more than one request, as a call is answered by a
+// single part of the batch response.
+var ErrBatchMultipleRequests = errors.New("books: batched call made more than one request")
+
+// Batch queues service calls to send them together in multipart batch requests, instead of one request each.
+//
+//	batch := client.NewBatch()
+//	for _, id := range volumeIDs {
+//		id := id
+//		batch.Queue(func(ctx context.Context) error {
+//			_, err := client.Shelves.AddVolumeContext(ctx, books.ShelfToRead, id, nil)
+//			return err
+//		})
+//	}
+//	err := batch.Do(ctx)
+//
+// Queued calls must pass the context they are given to the service method, and make a single request. Their
+// results are returned by the service method as usual, once Do sends the batch.
+type Batch struct {
+	client *Client
+	calls  []*BatchCall
+}
+
+// BatchCall is a call queued in a Batch.
+type BatchCall struct {
+	fn func(ctx context.Context) error
+
+	// Err is the error returned by the call, once the batch is done.
+	Err error
+}
+
+// NewBatch returns an empty batch of calls to send with the client.
+func (c *Client) NewBatch() *Batch {
+	return &Batch{client: c}
+}
+
+// Queue adds a call to the batch. fn is run by Do, with a context binding its request to the batch.
+func (b *Batch) Queue(fn func(ctx context.Context) error) *BatchCall {
+	call := &BatchCall{fn: fn}
+	b.calls = append(b.calls, call)
+	return call
+}
+
+// Len returns the number of calls queued in the batch.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// Do runs the queued calls, sending their requests in batches of at most MaxBatchSize. The error of each call is
+// returned by it and stored in its BatchCall. Do returns the first error of the calls, if any, and empties the
+// batch.
+func (b *Batch) Do(ctx context.Context) error {
+	calls := b.calls
+	b.calls = nil
+
+	for start := 0; start < len(calls); start += MaxBatchSize {
+		end := start + MaxBatchSize
+		if end > len(calls) {
+			end = len(calls)
+		}
+		b.client.runBatch(ctx, calls[start:end])
+	}
+
+	for _, call := range calls {
+		if call.Err != nil {
+			return call.Err
+		}
+	}
+	return nil
+}
+
+// batchSlot connects a queued call running in its own goroutine to the batch sending its request.
+type batchSlot struct {
+	requests  chan *http.Request
+	responses chan batchResult
+	done      chan error
+	sent      bool
+}
+
+type batchResult struct {
+	resp *http.Response
+	err  error
+}
+
+type batchKey struct{}
+
+// submit hands req to the batch and waits for its part of the batch response.
+func (s *batchSlot) submit(req *http.Request) (*http.Response, error) {
+	if s.sent {
+		return nil, ErrBatchMultipleRequests
+	}
+	s.sent = true
+
+	s.requests <- req
+	r := <-s.responses
+	return r.resp, r.err
+}
+
+// batchSlotFromContext returns the batch slot of a queued call, if ctx belongs to one.
+func batchSlotFromContext(ctx context.Context) *batchSlot {
+	slot, _ := ctx.Value(batchKey{}).(*batchSlot)
+	return slot
+}
+
+// runBatch runs calls, collects their requests and answers them with a single batch request.
+func (c *Client) runBatch(ctx context.Context, calls []*BatchCall) {
+	slots := make([]*batchSlot, len(calls))
+	for i, call := range calls {
+		slot := &batchSlot{
+			requests:  make(chan *http.Request, 1),
+			responses: make(chan batchResult, 1),
+			done:      make(chan error, 1),
+		}
+		slots[i] = slot
+
+		go func(fn func(context.Context) error) {
+			slot.done <- fn(context.WithValue(ctx, batchKey{}, slot))
+		}(call.fn)
+	}
+
+	// Calls either make their request or finish without one, for example on invalid arguments.
+	var reqs []*http.Request
+	var waiting []*batchSlot
+	for i, slot := range slots {
+		select {
+		case req := <-slot.requests:
+			reqs = append(reqs, req)
+			waiting = append(waiting, slot)
+		case err := <-slot.done:
+			calls[i].Err = err
+			slots[i] = nil
+		}
+	}
+
+	if len(reqs) > 0 {
+		results := c.sendBatch(ctx, reqs)
+		for i, slot := range waiting {
+			slot.responses <- results[i]
+		}
+	}
+
+	for i, slot := range slots {
+		if slot != nil {
+			calls[i].Err = <-slot.done
+		}
+	}
+}
+
+// batchURL returns the batch endpoint of the API, such as https://www.googleapis.com/batch/books/v1.
+func (c *Client) batchURL() string {
+	u := *c.BaseURL
+	u.Path = "/batch" + strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+	return u.String()
+}
+
+// sendBatch sends reqs in one multipart request, and returns the response to each of them.
+func (c *Client) sendBatch(ctx context.Context, reqs []*http.Request) []batchResult {
+	results := make([]batchResult, len(reqs))
+	fail := func(err error) []batchResult {
+		for i := range results {
+			results[i] = batchResult{err: err}
+		}
+		return results
+	}
+
+	body := new(bytes.Buffer)
+	mw := multipart.NewWriter(body)
+	for i, req := range reqs {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", "application/http")
+		header.Set("Content-ID", "<item-"+strconv.Itoa(i)+">")
+		part, err := mw.CreatePart(header)
+		if err != nil {
+			return fail(err)
+		}
+		if err := req.Write(part); err != nil {
+			return fail(err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return fail(err)
+	}
+
+	// The envelope is built by the client, so it carries the same credentials as the calls it holds, and sent
+	// through the middleware chain, so that instrumentation sees it like any other call.
+	op := Operation{Service: "Batch", Name: "batch"}
+	req, err := c.NewRequestWithContext(withOperation(ctx, op), "POST", c.batchURL(), nil)
+	if err != nil {
+		return fail(err)
+	}
+	data := body.Bytes()
+	req.Body = ioutil.NopCloser(bytes.NewReader(data))
+	req.GetBody = func() (io.ReadCloser, error) { return ioutil.NopCloser(bytes.NewReader(data)), nil }
+	req.ContentLength = int64(len(data))
+	req.Header.Set("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+	req.Header.Del("Accept")
+
+	respBody := new(bytes.Buffer)
+	resp, err := c.handler()(&Call{Operation: op, Request: req, Result: respBody})
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+		return fail(err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return fail(fmt.Errorf("books: batch response has content type %q", resp.Header.Get("Content-Type")))
+	}
+
+	answered := make([]bool, len(reqs))
+	mr := multipart.NewReader(respBody, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			break
+		}
+
+		id := strings.TrimPrefix(strings.Trim(part.Header.Get("Content-ID"), "<>"), "response-")
+		i, err := strconv.Atoi(strings.TrimPrefix(id, "item-"))
+		if err != nil || i < 0 || i >= len(reqs) || answered[i] {
+			continue
+		}
+		answered[i] = true
+		results[i] = readBatchPart(part, reqs[i])
+	}
+
+	for i := range reqs {
+		if !answered[i] {
+			results[i] = batchResult{err: fmt.Errorf("books: batch response has no part for %s %s", reqs[i].Method, redactURL(reqs[i].URL))}
+		}
+	}
+	return results
+}
+
+// readBatchPart reads the response to req held by a part of a batch response.
+func readBatchPart(part *multipart.Part, req *http.Request) batchResult {
+	resp, err := http.ReadResponse(bufio.NewReader(part), req)
+	if err != nil {
+		return batchResult{err: err}
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return batchResult{err: err}
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return batchResult{resp: resp, err: CheckResponse(resp)}
+}
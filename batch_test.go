@@ -0,0 +1,271 @@
+package books
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// batchServer records the batch requests received by handleBatch.
+type batchServer struct {
+	sizes    []int
+	requests []*http.Request
+}
+
+// handleBatch serves batch requests on mux, answering each part with the handler mux has for it.
+func handleBatch(t *testing.T) *batchServer {
+	server := new(batchServer)
+	mux.HandleFunc("/batch", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		server.requests = append(server.requests, r)
+
+		mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || mediaType != "multipart/mixed" {
+			t.Fatalf("batch Content-Type = %q", r.Header.Get("Content-Type"))
+		}
+
+		// The server can not write the response before the request body is read.
+		var reqs []*http.Request
+		var ids []string
+		in := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := in.NextPart()
+			if err != nil {
+				break
+			}
+
+			if got := part.Header.Get("Content-Type"); got != "application/http" {
+				t.Errorf("part Content-Type = %q, expected application/http", got)
+			}
+			req, err := http.ReadRequest(bufio.NewReader(part))
+			if err != nil {
+				t.Fatalf("reading part: %v", err)
+			}
+			body, _ := ioutil.ReadAll(req.Body)
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			reqs = append(reqs, req)
+			ids = append(ids, strings.Trim(part.Header.Get("Content-ID"), "<>"))
+		}
+
+		out := multipart.NewWriter(w)
+		w.Header().Set("Content-Type", "multipart/mixed; boundary="+out.Boundary())
+		for i, req := range reqs {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			header := textproto.MIMEHeader{}
+			header.Set("Content-Type", "application/http")
+			header.Set("Content-ID", "<response-"+ids[i]+">")
+			part, _ := out.CreatePart(header)
+			rec.Result().Write(part)
+		}
+		out.Close()
+
+		server.sizes = append(server.sizes, len(reqs))
+	})
+	return server
+}
+
+func TestBatch_split(t *testing.T) {
+	setup()
+	defer teardown()
+
+	server := handleBatch(t)
+
+	added := map[string]bool{}
+	mux.HandleFunc("/mylibrary/bookshelves/2/addVolume", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		added[r.URL.Query().Get("volumeId")] = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	batch := client.NewBatch()
+	for i := 0; i < 250; i++ {
+		id := fmt.Sprintf("v%d", i)
+		batch.Queue(func(ctx context.Context) error {
+			_, err := client.Shelves.AddVolumeContext(ctx, ShelfToRead, id, nil)
+			return err
+		})
+	}
+
+	if err := batch.Do(context.Background()); err != nil {
+		t.Fatalf("Do() returned an error: %v", err)
+	}
+	if want := []int{100, 100, 50}; !reflect.DeepEqual(server.sizes, want) {
+		t.Errorf("batch sizes = %v, expected %v", server.sizes, want)
+	}
+	if len(added) != 250 {
+		t.Errorf("added %d volumes, expected 250", len(added))
+	}
+	if batch.Len() != 0 {
+		t.Errorf("Len() = %d after Do, expected 0", batch.Len())
+	}
+}
+
+func TestBatch_results(t *testing.T) {
+	setup()
+	defer teardown()
+
+	server := handleBatch(t)
+
+	mux.HandleFunc("/volumes/v1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"v1","volumeInfo":{"title":"Go"}}`)
+	})
+	mux.HandleFunc("/volumes/v2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"code":404,"message":"Not Found","errors":[{"reason":"notFound"}]}}`)
+	})
+
+	var volume *Volume
+	var getErr error
+
+	batch := client.NewBatch()
+	found := batch.Queue(func(ctx context.Context) (err error) {
+		volume, _, err = client.Volumes.GetContext(ctx, "v1", nil)
+		return err
+	})
+	missing := batch.Queue(func(ctx context.Context) error {
+		_, _, getErr = client.Volumes.GetContext(ctx, "v2", nil)
+		return getErr
+	})
+	invalid := batch.Queue(func(ctx context.Context) error {
+		_, _, err := client.Shelves.GetContext(ctx, ShelfID(-1), nil)
+		return err
+	})
+
+	err := batch.Do(context.Background())
+	if !IsNotFound(err) {
+		t.Errorf("Do() returned %v, expected the first call error", err)
+	}
+
+	if found.Err != nil || volume == nil || *volume.Info.Title != "Go" {
+		t.Errorf("found call returned %+v, %v", volume, found.Err)
+	}
+	if !IsNotFound(missing.Err) || missing.Err != getErr {
+		t.Errorf("missing call error = %v, expected not found", missing.Err)
+	}
+	if invalid.Err == nil {
+		t.Error("invalid call returned no error")
+	}
+	if want := []int{2}; !reflect.DeepEqual(server.sizes, want) {
+		t.Errorf("batch sizes = %v, expected %v", server.sizes, want)
+	}
+}
+
+func TestBatch_multipleRequests(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleBatch(t)
+	mux.HandleFunc("/mylibrary/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items":[]}`)
+	})
+
+	batch := client.NewBatch()
+	call := batch.Queue(func(ctx context.Context) error {
+		if _, _, err := client.Shelves.ListContext(ctx, nil); err != nil {
+			return err
+		}
+		_, _, err := client.Shelves.ListContext(ctx, nil)
+		return err
+	})
+
+	batch.Do(context.Background())
+	if call.Err != ErrBatchMultipleRequests {
+		t.Errorf("call error = %v, expected ErrBatchMultipleRequests", call.Err)
+	}
+}
+
+func TestBatch_credentials(t *testing.T) {
+	setup()
+	defer teardown()
+
+	SetToken("token")(client)
+	SetAPIKey("api-key")(client)
+	SetUserAgent("batch-test")(client)
+
+	server := handleBatch(t)
+	mux.HandleFunc("/volumes/v1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"v1"}`)
+	})
+
+	batch := client.NewBatch()
+	batch.Queue(func(ctx context.Context) error {
+		_, _, err := client.Volumes.GetContext(ctx, "v1", nil)
+		return err
+	})
+	if err := batch.Do(context.Background()); err != nil {
+		t.Fatalf("Do() returned an error: %v", err)
+	}
+
+	if len(server.requests) != 1 {
+		t.Fatalf("server got %d batch requests, expected 1", len(server.requests))
+	}
+	envelope := server.requests[0]
+	if got := envelope.Header.Get("Authorization"); got != "Bearer token" {
+		t.Errorf("Authorization = %q, expected the token", got)
+	}
+	if got := envelope.URL.Query().Get("key"); got != "api-key" {
+		t.Errorf("key = %q, expected the API key", got)
+	}
+	if got := envelope.Header.Get("User-Agent"); got != client.UserAgent {
+		t.Errorf("User-Agent = %q, expected %q", got, client.UserAgent)
+	}
+}
+
+func TestBatch_middleware(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var names []string
+	SetMiddleware(func(next Handler) Handler {
+		return func(call *Call) (*Response, error) {
+			mu.Lock()
+			names = append(names, call.Operation.Name)
+			mu.Unlock()
+			return next(call)
+		}
+	})(client)
+
+	handleBatch(t)
+	mux.HandleFunc("/volumes/v1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"v1"}`)
+	})
+
+	batch := client.NewBatch()
+	for i := 0; i < 2; i++ {
+		batch.Queue(func(ctx context.Context) error {
+			_, _, err := client.Volumes.GetContext(ctx, "v1", nil)
+			return err
+		})
+	}
+	if err := batch.Do(context.Background()); err != nil {
+		t.Fatalf("Do() returned an error: %v", err)
+	}
+
+	sort.Strings(names)
+	if expected := []string{"batch", "books.volumes.get", "books.volumes.get"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("middleware saw %v, expected %v", names, expected)
+	}
+}
+
+func TestBatchURL(t *testing.T) {
+	c := NewClient(nil)
+	if got, want := c.batchURL(), "https://www.googleapis.com/batch/books/v1"; got != want {
+		t.Errorf("batchURL() = %q, expected %q", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package books
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,6 +10,8 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/google/go-querystring/query"
 )
@@ -20,6 +23,9 @@ const (
 	mediaType      = "application/json"
 )
 
+// maxPageSize is the largest maxResults accepted by the list API calls.
+const maxPageSize = 40
+
 // Client manages communication with Google Books V1 API.
 type Client struct {
 	// HTTP client used to communicate with the DO API.
@@ -31,6 +37,30 @@ type Client struct {
 	// token used to make authenticated API calls.
 	token string
 
+	// apiKey used to make unauthenticated calls to public data.
+	apiKey string
+
+	// retryPolicy configures the retries of failed requests, nil disables them.
+	retryPolicy *RetryPolicy
+
+	// rateLimiter delays requests to stay within the API quotas, nil disables it.
+	rateLimiter RateLimiter
+
+	// strictDecoding makes responses with fields missing from the decoded type an error.
+	strictDecoding bool
+
+	// logger receives a log of every attempt, nil disables logging.
+	logger Logger
+
+	// logBodies adds request and response bodies to the logs.
+	logBodies bool
+
+	// middleware wraps the call path of Do, the first one is the outermost.
+	middleware []Middleware
+
+	// cache stores responses to GET requests, if set.
+	cache Cache
+
 	// User agent for client
 	UserAgent string
 
@@ -42,6 +72,15 @@ type Client struct {
 
 	// Service used to talk to books.mylibrary.bookshelves.list API.
 	Shelves ShelvesService
+
+	// Service used to talk to books.mylibrary.readingpositions API.
+	ReadingPositions ReadingPositionsService
+
+	// Service used to talk to books.layers API.
+	Layers LayersService
+
+	// Service used to talk to books.myconfig API.
+	Config ConfigService
 }
 
 // Response is a Google Books response. This wraps the standard http.Response returned from Google Books.
@@ -50,6 +89,12 @@ type Response struct {
 
 	// NextPageToken is used on the response to fetch the next page.
 	NextPageToken string
+
+	// TotalItems is the total number of items of a list response, across all pages.
+	TotalItems int
+
+	// Cached reports whether the response was served from the cache of the client, see SetCache.
+	Cached bool
 }
 
 // An ErrorResponse reports the error caused by an API request
@@ -62,22 +107,33 @@ type ErrorResponse struct {
 }
 
 // Error contains an error response from the server.
+// https://cloud.google.com/apis/design/errors#http_mapping
 type Error struct {
 	// Code is the HTTP response status code and will always be populated.
 	Code int `json:"code"`
 	// Message is the server response message and is only populated when
 	// explicitly referenced by the JSON server response.
 	Message string `json:"message"`
+	// Status is the canonical error code. For example: "NOT_FOUND".
+	Status string `json:"status"`
 
-	Errors []ErrorItem
+	Errors []ErrorItem `json:"errors"`
 }
 
 // ErrorItem is a detailed error code & message from the Google API frontend.
 type ErrorItem struct {
+	// Domain is the scope of the error. For example: "global" or "usageLimits".
+	Domain string `json:"domain"`
 	// Reason is the typed error code. For example: "some_example".
 	Reason string `json:"reason"`
 	// Message is the human-readable description of the error.
 	Message string `json:"message"`
+	// Location is the name of the request element that caused the error. For example: "Authorization".
+	Location string `json:"location"`
+	// LocationType tells how to interpret Location. For example: "header" or "parameter".
+	LocationType string `json:"locationType"`
+	// ExtendedHelp is a URL to documentation about the error.
+	ExtendedHelp string `json:"extendedHelp"`
 }
 
 // addOptions adds the parameters in opt as URL query parameters to s.
@@ -114,6 +170,9 @@ func NewClient(httpClient *http.Client) *Client {
 	c.Annotations = &GoogleAnnotationsService{client: c}
 	c.Volumes = &GoogleVolumesService{client: c}
 	c.Shelves = &GoogleShelvesService{client: c}
+	c.ReadingPositions = &GoogleReadingPositionsService{client: c}
+	c.Layers = newGoogleLayersService(c)
+	c.Config = &GoogleConfigService{client: c}
 
 	return c
 }
@@ -162,16 +221,36 @@ func SetToken(token string) ClientOpt {
 	}
 }
 
+// SetAPIKey is a client option for setting the API key sent with every request. An API key is enough to
+// access public data, such as the public bookshelves of other users, without an oauth token.
+func SetAPIKey(key string) ClientOpt {
+	return func(c *Client) error {
+		c.apiKey = key
+		return nil
+	}
+}
+
 // NewRequest creates an API request. A relative URL can be provided in urlStr, which will be resolved to the
 // BaseURL of the Client. Relative URLS should always be specified without a preceding slash. If specified, the
 // value pointed to by body is JSON encoded and included in as the request body.
 func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, urlStr, body)
+}
+
+// NewRequestWithContext is NewRequest with a context. The context controls the lifetime of the request, it is
+// used to cancel the request or set a deadline for it.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
 	rel, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, err
 	}
 
 	u := c.BaseURL.ResolveReference(rel)
+	if c.apiKey != "" {
+		q := u.Query()
+		q.Set("key", c.apiKey)
+		u.RawQuery = q.Encode()
+	}
 
 	var buf io.ReadWriter
 	if body != nil {
@@ -182,7 +261,7 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
 	if err != nil {
 		return nil, err
 	}
@@ -198,13 +277,6 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 		req.Header.Set("Authorization", "Bearer "+c.token)
 	}
 
-	// out, err := httputil.DumpRequestOut(req, true)
-	// if err != nil {
-	// 	log.Fatal(err)
-	// }
-	// fmt.Println(strings.Replace(string(out), "\r", "", -1))
-	// fmt.Println("--newRequest--")
-
 	return req, nil
 }
 
@@ -217,10 +289,37 @@ func newResponse(r *http.Response) *Response {
 
 // Do sends an API request and returns the API response. The API response is JSON decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
-// the raw response will be written to v, without attempting to decode it.
+// the raw response will be written to v, without attempting to decode it. A response body that can not be
+// decoded into v is reported as a *DecodeError.
+//
+// The call goes through the middleware of the client, see SetMiddleware. If the client has a cache, GET requests
+// are served from it or revalidated, see SetCache. If the client has a retry policy, requests failing with a
+// transient error are retried before returning.
+//
+// The request is bound to the context of req. If the context is canceled or its deadline is exceeded, the
+// context's error is returned, so callers can test for it with errors.Is(err, context.Canceled).
 func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
-	resp, err := c.client.Do(req)
-	if err != nil {
+	op, _ := OperationFromContext(req.Context())
+	return c.handler()(&Call{Operation: op, Request: req, Result: v})
+}
+
+// do sends req and decodes its response into v, it is the innermost handler of the middleware chain.
+func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
+	ctx := req.Context()
+
+	var resp *http.Response
+	var cached bool
+	var err error
+	if slot := batchSlotFromContext(ctx); slot != nil {
+		resp, err = slot.submit(req)
+	} else {
+		resp, cached, err = c.sendCached(req)
+	}
+	if resp == nil {
+		// If the context was canceled, its error is more useful than the transport's.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, err
 	}
 	defer func() {
@@ -230,15 +329,8 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 	}()
 
 	response := newResponse(resp)
+	response.Cached = cached
 
-	// outResp, err := httputil.DumpResponse(resp, true)
-	// if err != nil {
-	// 	log.Fatal(err)
-	// }
-	// fmt.Println(strings.Replace(string(outResp), "\r", "", -1))
-	// fmt.Println("-Do---")
-
-	err = CheckResponse(resp)
 	if err != nil {
 		return response, err
 	}
@@ -247,12 +339,18 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 		if w, ok := v.(io.Writer); ok {
 			_, err := io.Copy(w, resp.Body)
 			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return nil, ctxErr
+				}
 				return nil, err
 			}
 		} else {
-			err := json.NewDecoder(resp.Body).Decode(v)
-			if err != io.EOF {
-				err = nil // ignore EOF
+			err := decodeJSON(resp.Body, v, c.strictDecoding)
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return response, ctxErr
+				}
+				return response, err
 			}
 		}
 	}
@@ -260,12 +358,24 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 	return response, err
 }
 func (r *ErrorResponse) Error() string {
-	return fmt.Sprintf("%v", r.CustomError.Message)
+	msg := r.CustomError.Message
+	if reason := r.Reason(); reason != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, reason)
+	}
+
+	if r.Response == nil {
+		return msg
+	}
+	if req := r.Response.Request; req != nil && req.URL != nil {
+		return fmt.Sprintf("%v %v: %d %v", req.Method, redactURL(req.URL), r.Response.StatusCode, msg)
+	}
+	return fmt.Sprintf("%d %v", r.Response.StatusCode, msg)
 }
 
 // CheckResponse checks the API response for errors, and returns them if present. A response is considered an
-// error if it has a status code outside the 200 range. API error responses are expected to have either no response
-// body, or a JSON response body that maps to ErrorResponse. Any other response body will be silently ignored.
+// error if it has a status code outside the 200 range, and is then returned as an *ErrorResponse. API error
+// responses are expected to have either no response body, or a JSON response body that maps to ErrorResponse.
+// Any other response body, such as an HTML error page, is used as the error message.
 func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; c >= 200 && c <= 299 {
 		return nil
@@ -274,15 +384,31 @@ func CheckResponse(r *http.Response) error {
 	errorResponse := &ErrorResponse{Response: r}
 	data, err := ioutil.ReadAll(r.Body)
 	if err == nil && len(data) > 0 {
-		err := json.Unmarshal(data, errorResponse)
-		if err != nil {
-			return err
+		if err := json.Unmarshal(data, errorResponse); err != nil {
+			errorResponse.CustomError = Error{Code: r.StatusCode, Message: errorBodyMessage(data)}
 		}
 	}
 
 	return errorResponse
 }
 
+// maxErrorBodyMessage is the length at which error bodies that are not JSON are truncated in error messages.
+const maxErrorBodyMessage = 512
+
+// errorBodyMessage returns an error body that is not JSON as an error message.
+func errorBodyMessage(data []byte) string {
+	msg := strings.TrimSpace(string(data))
+	if len(msg) > maxErrorBodyMessage {
+		// Back off to the start of a rune so that the message stays valid UTF-8.
+		i := maxErrorBodyMessage
+		for i > 0 && !utf8.RuneStart(msg[i]) {
+			i--
+		}
+		msg = msg[:i] + "..."
+	}
+	return msg
+}
+
 // String is a helper function that allocates a new string value
 func String(v string) *string { return &v }
 
@@ -291,3 +417,6 @@ func Bool(v bool) *bool { return &v }
 
 // Int is a helper function that alloates a new int value
 func Int(v int) *int { return &v }
+
+// Float64 is a helper function that allocates a new float64 value
+func Float64(v float64) *float64 { return &v }
@@ -1,15 +1,19 @@
 package books
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 var (
@@ -57,6 +61,36 @@ func testFormValues(t *testing.T, r *http.Request, values values) {
 	}
 }
 
+// loadFixture returns the contents of a recorded API payload from testdata.
+func loadFixture(t *testing.T, name string) []byte {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("loadFixture(%q): %v", name, err)
+	}
+	return data
+}
+
+// testJSONRoundTrip checks that encoding v yields the same document as golden, so that no field of the
+// recorded payload was dropped while decoding into v.
+func testJSONRoundTrip(t *testing.T, golden []byte, v interface{}) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal(): %v", err)
+	}
+
+	var got, expected interface{}
+	if err := json.Unmarshal(encoded, &got); err != nil {
+		t.Fatalf("json.Unmarshal(encoded): %v", err)
+	}
+	if err := json.Unmarshal(golden, &expected); err != nil {
+		t.Fatalf("json.Unmarshal(golden): %v", err)
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("round trip = %s\nexpected %s", encoded, golden)
+	}
+}
+
 func testURLParseError(t *testing.T, err error) {
 	if err == nil {
 		t.Errorf("Expected error to be returned")
@@ -378,3 +412,101 @@ func TestAddOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestNewRequest_withAPIKey(t *testing.T) {
+	c, err := New(nil, SetAPIKey("api-key"))
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+
+	req, _ := c.NewRequest("GET", "users/1127/bookshelves?source=app", nil)
+
+	expected := url.Values{"key": {"api-key"}, "source": {"app"}}
+	if got := req.URL.Query(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("NewRequest() query = %v; expected %v", got, expected)
+	}
+}
+
+func TestDo_canceledContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Request should not have been sent")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, _ := client.NewRequestWithContext(ctx, "GET", "/", nil)
+	_, err := client.Do(req, nil)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Do() error = %v, expected %v", err, context.Canceled)
+	}
+}
+
+func TestDo_deadlineExceeded(t *testing.T) {
+	setup()
+	defer teardown()
+
+	done := make(chan struct{})
+	defer close(done)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, _ := client.NewRequestWithContext(ctx, "GET", "/", nil)
+	_, err := client.Do(req, nil)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() error = %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+// cancelingBody cancels its context on the first read, as if the context was canceled while the response
+// body was being read, and records whether it was closed.
+type cancelingBody struct {
+	cancel context.CancelFunc
+	closed bool
+}
+
+func (b *cancelingBody) Read(p []byte) (int, error) {
+	b.cancel()
+	return 0, context.Canceled
+}
+
+func (b *cancelingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestDo_canceledWhileReadingBody(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	body := &cancelingBody{cancel: cancel}
+	c := NewClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: body, Request: r}, nil
+	})})
+
+	req, _ := c.NewRequestWithContext(ctx, "GET", "/", nil)
+	_, err := c.Do(req, new(json.RawMessage))
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Do() error = %v, expected %v", err, context.Canceled)
+	}
+	if !body.closed {
+		t.Error("Do() did not close the response body")
+	}
+}
@@ -0,0 +1,344 @@
+package books
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a response stored in a Cache.
+type CacheEntry struct {
+	ETag       string      `json:"etag,omitempty"`
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+
+	// Expires is the time until which the entry can be used without revalidating it. It is the zero time for
+	// entries that must always be revalidated.
+	Expires time.Time `json:"expires"`
+}
+
+// fresh reports whether the entry can be used at now without revalidating it.
+func (e *CacheEntry) fresh(now time.Time) bool {
+	return now.Before(e.Expires)
+}
+
+// response returns the entry as a response to req.
+func (e *CacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// Cache stores responses to GET requests, keyed by request. Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry)
+	Delete(key string)
+}
+
+// SetCache is a client option for caching responses to GET requests in c. Cached responses are served while
+// fresh according to their Cache-Control header, and revalidated with If-None-Match once stale.
+//
+// Requests are keyed by URL and user, as identified by WithRateLimitKey or the Authorization header. Requests
+// without a user are only cached when their sole credential is the API key of the client and the http.Client
+// has the default transport, since a transport such as the oauth2 one adds the user's token after the cache.
+// Use WithRateLimitKey to cache the requests of such clients.
+func SetCache(c Cache) ClientOpt {
+	return func(client *Client) error {
+		client.cache = c
+		return nil
+	}
+}
+
+// sendCached sends req through the cache of the client, if it has one. It reports whether the response was
+// served from the cache.
+func (c *Client) sendCached(req *http.Request) (*http.Response, bool, error) {
+	if c.cache == nil || req.Method != http.MethodGet || hasCacheDirective(req.Header, "no-store") {
+		resp, err := c.send(req)
+		return resp, false, err
+	}
+
+	key, ok := c.cacheKey(req)
+	if !ok {
+		resp, err := c.send(req)
+		return resp, false, err
+	}
+
+	entry, ok := c.cache.Get(key)
+	if ok && entry.fresh(time.Now()) && !hasCacheDirective(req.Header, "no-cache") {
+		return entry.response(req), true, nil
+	}
+	if ok && entry.ETag != "" {
+		req = req.Clone(context.WithValue(req.Context(), revalidationKey{}, true))
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	resp, err := c.send(req)
+	if ok && entry.ETag != "" && resp != nil && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+
+		updated := *entry
+		updated.Expires = cacheExpiry(resp.Header, time.Now())
+		c.cache.Set(key, &updated)
+		return updated.response(req), true, nil
+	}
+
+	if err == nil {
+		err = c.storeResponse(key, resp)
+	}
+	return resp, false, err
+}
+
+// cacheKey returns the key req is cached under, and false if the user req is sent as is unknown, so that its
+// response can not be told apart from the responses to other users.
+func (c *Client) cacheKey(req *http.Request) (string, bool) {
+	key := req.URL.String()
+	if user := requestRateLimitKey(req); user != "" {
+		return key + " " + user, true
+	}
+
+	t := c.client.Transport
+	if req.URL.Query().Get("key") == "" || (t != nil && t != http.DefaultTransport) {
+		return "", false
+	}
+	return key, true
+}
+
+type revalidationKey struct{}
+
+// isRevalidation reports whether req revalidates a cached response, in which case a 304 Not Modified response is
+// a success rather than an error.
+func isRevalidation(req *http.Request) bool {
+	revalidation, _ := req.Context().Value(revalidationKey{}).(bool)
+	return revalidation
+}
+
+// storeResponse adds resp to the cache, if it can be reused. The body of resp is replaced by a buffered copy.
+func (c *Client) storeResponse(key string, resp *http.Response) error {
+	if hasCacheDirective(resp.Header, "no-store") {
+		c.cache.Delete(key)
+		return nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	expires := cacheExpiry(resp.Header, time.Now())
+	if etag == "" && expires.IsZero() {
+		return nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	c.cache.Set(key, &CacheEntry{
+		ETag:       etag,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+		Expires:    expires,
+	})
+	return nil
+}
+
+// cacheExpiry returns the time until which a response with header h is fresh, or the zero time if it must be
+// revalidated before use.
+func cacheExpiry(h http.Header, now time.Time) time.Time {
+	if hasCacheDirective(h, "no-cache") {
+		return time.Time{}
+	}
+
+	maxAge, ok := cacheDirective(h, "max-age")
+	if !ok {
+		return time.Time{}
+	}
+	seconds, err := strconv.Atoi(maxAge)
+	if err != nil || seconds <= 0 {
+		return time.Time{}
+	}
+	return now.Add(time.Duration(seconds) * time.Second)
+}
+
+// cacheDirective returns the value of the Cache-Control directive name in h.
+func cacheDirective(h http.Header, name string) (string, bool) {
+	for _, field := range h.Values("Cache-Control") {
+		for _, directive := range strings.Split(field, ",") {
+			directive = strings.TrimSpace(directive)
+			key, value := directive, ""
+			if i := strings.Index(directive, "="); i >= 0 {
+				key, value = directive[:i], strings.Trim(directive[i+1:], `"`)
+			}
+			if strings.EqualFold(key, name) {
+				return value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// hasCacheDirective reports whether h has the Cache-Control directive name.
+func hasCacheDirective(h http.Header, name string) bool {
+	_, ok := cacheDirective(h, name)
+	return ok
+}
+
+// MemoryCache is a Cache keeping the most recently used entries in memory.
+type MemoryCache struct {
+	size int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// memoryCacheItem is an element of the recency list of a MemoryCache.
+type memoryCacheItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryCache returns a MemoryCache holding at most size entries. A size less than 1 means no limit.
+func NewMemoryCache(size int) *MemoryCache {
+	return &MemoryCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the entry stored under key, and marks it as the most recently used.
+func (m *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(e)
+	return e.Value.(*memoryCacheItem).entry, true
+}
+
+// Set stores entry under key, evicting the least recently used entry if the cache is full.
+func (m *MemoryCache) Set(key string, entry *CacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.entries[key]; ok {
+		e.Value.(*memoryCacheItem).entry = entry
+		m.order.MoveToFront(e)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+	if m.size > 0 && m.order.Len() > m.size {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+// Delete removes the entry stored under key.
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.entries[key]; ok {
+		m.order.Remove(e)
+		delete(m.entries, key)
+	}
+}
+
+// Len returns the number of entries in the cache.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.order.Len()
+}
+
+// FileCache is a Cache storing each entry as a JSON file in a directory, so that it survives restarts. Entries
+// that can not be read are treated as missing.
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache returns a FileCache storing its entries in dir, which is created if needed.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+// path returns the file of the entry stored under key. Keys are hashed, as they may contain API keys.
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the entry stored under key.
+func (f *FileCache) Get(key string) (*CacheEntry, bool) {
+	data, err := ioutil.ReadFile(f.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	entry := new(CacheEntry)
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, false
+	}
+	return entry, true
+}
+
+// Set stores entry under key. The file is replaced atomically, so concurrent readers never see a partial entry.
+func (f *FileCache) Set(key string, entry *CacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	tmp, err := ioutil.TempFile(f.dir, ".entry-")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+
+	if err := os.Rename(tmp.Name(), f.path(key)); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// Delete removes the entry stored under key.
+func (f *FileCache) Delete(key string) {
+	os.Remove(f.path(key))
+}
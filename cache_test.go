@@ -0,0 +1,262 @@
+package books
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCache_revalidate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	SetCache(NewMemoryCache(10))(client)
+	SetToken("token")(client)
+
+	requests := 0
+	mux.HandleFunc("/mylibrary/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, `{"items":[{"id":3,"title":"Reading now"}]}`)
+	})
+
+	first, resp, err := client.Shelves.List(nil)
+	if err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+	if resp.Cached {
+		t.Error("first response reported as cached")
+	}
+
+	second, resp, err := client.Shelves.List(nil)
+	if err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+	if !resp.Cached || resp.StatusCode != http.StatusOK {
+		t.Errorf("second response Cached = %v, StatusCode = %d, expected a cached 200", resp.Cached, resp.StatusCode)
+	}
+	if requests != 2 {
+		t.Errorf("server got %d requests, expected 2", requests)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("cached result = %+v, expected %+v", second, first)
+	}
+}
+
+func TestCache_maxAge(t *testing.T) {
+	setup()
+	defer teardown()
+
+	SetCache(NewMemoryCache(10))(client)
+	SetAPIKey("key")(client)
+
+	requests := 0
+	mux.HandleFunc("/volumes/v1", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "private, max-age=60")
+		fmt.Fprint(w, `{"id":"v1"}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		volume, resp, err := client.Volumes.Get("v1", nil)
+		if err != nil {
+			t.Fatalf("Get() returned an error: %v", err)
+		}
+		if *volume.ID != "v1" || resp.Cached != (i == 1) {
+			t.Errorf("call %d returned %+v, Cached = %v", i, volume, resp.Cached)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("server got %d requests, expected 1", requests)
+	}
+}
+
+func TestCache_noStore(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cache := NewMemoryCache(10)
+	SetCache(cache)(client)
+	SetAPIKey("key")(client)
+
+	mux.HandleFunc("/volumes/v1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Cache-Control", "no-store")
+		fmt.Fprint(w, `{"id":"v1"}`)
+	})
+
+	if _, _, err := client.Volumes.Get("v1", nil); err != nil {
+		t.Fatalf("Get() returned an error: %v", err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("cache holds %d entries, expected none", cache.Len())
+	}
+}
+
+func TestCache_unknownUser(t *testing.T) {
+	cases := []struct {
+		name      string
+		transport http.RoundTripper
+		apiKey    string
+	}{
+		{"no credentials", nil, ""},
+		{"transport credentials", roundTripperFunc(http.DefaultTransport.RoundTrip), ""},
+		{"API key and transport credentials", roundTripperFunc(http.DefaultTransport.RoundTrip), "key"},
+	}
+
+	for _, c := range cases {
+		setup()
+
+		cache := NewMemoryCache(10)
+		client = NewClient(&http.Client{Transport: c.transport})
+		client.BaseURL, _ = url.Parse(server.URL)
+		SetCache(cache)(client)
+		SetAPIKey(c.apiKey)(client)
+
+		mux.HandleFunc("/mylibrary/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Cache-Control", "private, max-age=60")
+			fmt.Fprint(w, `{"items":[{"id":3,"title":"Reading now"}]}`)
+		})
+
+		if _, _, err := client.Shelves.List(nil); err != nil {
+			t.Fatalf("%s: List() returned an error: %v", c.name, err)
+		}
+		if cache.Len() != 0 {
+			t.Errorf("%s: cache holds %d entries, expected none", c.name, cache.Len())
+		}
+
+		teardown()
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	now := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		cacheControl string
+		want         time.Time
+	}{
+		{"", time.Time{}},
+		{"private, max-age=30", now.Add(30 * time.Second)},
+		{`max-age="30"`, now.Add(30 * time.Second)},
+		{"max-age=0", time.Time{}},
+		{"max-age=30, no-cache", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		h := http.Header{"Cache-Control": {tt.cacheControl}}
+		if got := cacheExpiry(h, now); !got.Equal(tt.want) {
+			t.Errorf("cacheExpiry(%q) = %v, expected %v", tt.cacheControl, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryCache_evicts(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set("a", &CacheEntry{ETag: "a"})
+	cache.Set("b", &CacheEntry{ETag: "b"})
+	cache.Get("a")
+	cache.Set("c", &CacheEntry{ETag: "c"})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("least recently used entry was not evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("entry %q was evicted", key)
+		}
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "books-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache, err := NewFileCache(dir)
+	if err != nil {
+		t.Fatalf("NewFileCache() returned an error: %v", err)
+	}
+
+	entry := &CacheEntry{
+		ETag:       `"v1"`,
+		StatusCode: 200,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       []byte(`{"id":"v1"}`),
+		Expires:    time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	cache.Set("https://example.com/volumes/v1?key=secret", entry)
+
+	// A new cache on the same directory sees the entry.
+	reopened, _ := NewFileCache(dir)
+	got, ok := reopened.Get("https://example.com/volumes/v1?key=secret")
+	if !ok || !reflect.DeepEqual(got, entry) {
+		t.Errorf("Get() = %+v, %v, expected %+v", got, ok, entry)
+	}
+
+	reopened.Delete("https://example.com/volumes/v1?key=secret")
+	if _, ok := cache.Get("https://example.com/volumes/v1?key=secret"); ok {
+		t.Error("entry was not deleted")
+	}
+}
+
+func TestCache_revalidationLogged(t *testing.T) {
+	setup()
+	defer teardown()
+
+	logger := &memoryLogger{}
+	SetLogger(logger)(client)
+	SetCache(NewMemoryCache(10))(client)
+	SetAPIKey("key")(client)
+	setupRetry(t, nil)
+
+	requests := 0
+	mux.HandleFunc("/volumes/v1", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, `{"id":"v1"}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Volumes.Get("v1", nil); err != nil {
+			t.Fatalf("Get() returned an error: %v", err)
+		}
+	}
+
+	if requests != 2 || len(logger.logs) != 2 {
+		t.Fatalf("server got %d requests and %d were logged, expected 2 and 2", requests, len(logger.logs))
+	}
+	if l := logger.logs[1]; l.Status != http.StatusNotModified || l.Err != nil {
+		t.Errorf("revalidation log = %+v, expected a 304 without error", l)
+	}
+}
+
+func TestCheckResponse_notModifiedWithoutCache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/volumes/v1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	})
+
+	req, _ := client.NewRequest("GET", "volumes/v1", nil)
+	req.Header.Set("If-None-Match", `"v1"`)
+	if _, err := client.Do(req, nil); err == nil {
+		t.Error("Do() returned no error for a 304 to a request the cache did not make")
+	}
+}
@@ -0,0 +1,335 @@
+package books
+
+import (
+	"context"
+	"errors"
+)
+
+// ConfigService defines the behavior required by types that want to implement a new Config type.
+type ConfigService interface {
+	GetUserSettings() (*UserSettings, *Response, error)
+	GetUserSettingsContext(context.Context) (*UserSettings, *Response, error)
+	UpdateUserSettings(*UserSettings) (*UserSettings, *Response, error)
+	UpdateUserSettingsContext(context.Context, *UserSettings) (*UserSettings, *Response, error)
+	SyncVolumeLicenses(string, string, *SyncVolumeLicensesOptions) ([]Volume, *Response, error)
+	SyncVolumeLicensesContext(context.Context, string, string, *SyncVolumeLicensesOptions) ([]Volume, *Response, error)
+	RequestAccess(string, string, string, *RequestAccessOptions) (*RequestAccessData, *Response, error)
+	RequestAccessContext(context.Context, string, string, string, *RequestAccessOptions) (*RequestAccessData, *Response, error)
+	ReleaseDownloadAccess([]string, string, *ReleaseDownloadAccessOptions) (*DownloadAccesses, *Response, error)
+	ReleaseDownloadAccessContext(context.Context, []string, string, *ReleaseDownloadAccessOptions) (*DownloadAccesses, *Response, error)
+}
+
+// GoogleConfigService implements the ConfigService interface.
+type GoogleConfigService struct {
+	client *Client
+}
+
+// UserSettings represents a Google Book Usersettings resource, the preferences of the user.
+// https://developers.google.com/books/docs/v1/reference/myconfig/getUserSettings
+type UserSettings struct {
+	Kind         *string               `json:"kind,omitempty"`
+	NotesExport  *NotesExportSettings  `json:"notesExport,omitempty"`
+	Notification *NotificationSettings `json:"notification,omitempty"`
+}
+
+// NotesExportSettings configures the export of the user's notes to a Google Drive folder.
+type NotesExportSettings struct {
+	FolderName *string `json:"folderName,omitempty"`
+	IsEnabled  *bool   `json:"isEnabled,omitempty"`
+}
+
+// NotificationSettings holds the user's choice for each kind of notification.
+type NotificationSettings struct {
+	MoreFromAuthors   *NotificationSetting `json:"moreFromAuthors,omitempty"`
+	MoreFromSeries    *NotificationSetting `json:"moreFromSeries,omitempty"`
+	RewardExpirations *NotificationSetting `json:"rewardExpirations,omitempty"`
+	PriceDrop         *NotificationSetting `json:"priceDrop,omitempty"`
+	MatchMyInterests  *NotificationSetting `json:"matchMyInterests,omitempty"`
+}
+
+// NotificationSetting is the user's choice for a kind of notification. OptedState is one of the OptedState
+// constants.
+type NotificationSetting struct {
+	OptedState *string `json:"opted_state,omitempty"`
+}
+
+// Values of NotificationSetting.OptedState.
+const (
+	OptedStateIn  = "OPTED_IN"
+	OptedStateOut = "OPTED_OUT"
+)
+
+// DownloadAccesses represents a Google Book DownloadAccesses resource, the download restrictions of volumes.
+type DownloadAccesses struct {
+	Kind               *string                     `json:"kind,omitempty"`
+	DownloadAccessList []DownloadAccessRestriction `json:"downloadAccessList,omitempty"`
+}
+
+// RequestAccessData represents a Google Book RequestAccessData resource, the result of a request for access to a
+// volume. Each restriction is signed, the nonce of the request is returned in it.
+type RequestAccessData struct {
+	Kind             *string                      `json:"kind,omitempty"`
+	ConcurrentAccess *ConcurrentAccessRestriction `json:"concurrentAccess,omitempty"`
+	DownloadAccess   *DownloadAccessRestriction   `json:"downloadAccess,omitempty"`
+}
+
+// ConcurrentAccessRestriction represents a Google Book ConcurrentAccessRestriction resource, the limit on the
+// number of devices reading a volume at the same time.
+type ConcurrentAccessRestriction struct {
+	Kind                 *string `json:"kind,omitempty"`
+	VolumeID             *string `json:"volumeId,omitempty"`
+	Restricted           *bool   `json:"restricted,omitempty"`
+	DeviceAllowed        *bool   `json:"deviceAllowed,omitempty"`
+	MaxConcurrentDevices *int    `json:"maxConcurrentDevices,omitempty"`
+	TimeWindowSeconds    *int    `json:"timeWindowSeconds,omitempty"`
+	Nonce                *string `json:"nonce,omitempty"`
+	Source               *string `json:"source,omitempty"`
+	ReasonCode           *string `json:"reasonCode,omitempty"`
+	Message              *string `json:"message,omitempty"`
+	Signature            *string `json:"signature,omitempty"`
+}
+
+// LicenseTypes selects the licenses requested by books.myconfig.requestAccess.
+type LicenseTypes string
+
+// Values accepted by the licenseTypes parameter.
+const (
+	LicenseTypesBoth       LicenseTypes = "BOTH"
+	LicenseTypesConcurrent LicenseTypes = "CONCURRENT"
+	LicenseTypesDownload   LicenseTypes = "DOWNLOAD"
+)
+
+// FeatureRentals is the value of the features parameter of books.myconfig.syncVolumeLicenses for clients
+// supporting rentals.
+const FeatureRentals = "RENTALS"
+
+// SyncVolumeLicensesOptions specifies the optional parameters for books.myconfig.syncVolumeLicenses.
+// VolumeIDs restricts the sync to the given volumes, Features lists the client features, such as FeatureRentals.
+type SyncVolumeLicensesOptions struct {
+	VolumeIDs              []string `url:"volumeIds,omitempty"`
+	Features               []string `url:"features,omitempty"`
+	IncludeNonComicsSeries bool     `url:"includeNonComicsSeries,omitempty"`
+	ShowPreorders          bool     `url:"showPreorders,omitempty"`
+	Locale                 string   `url:"locale,omitempty"`
+	Source                 string   `url:"source,omitempty"`
+}
+
+// RequestAccessOptions specifies the optional parameters for books.myconfig.requestAccess.
+type RequestAccessOptions struct {
+	LicenseTypes LicenseTypes `url:"licenseTypes,omitempty"`
+	Locale       string       `url:"locale,omitempty"`
+	Source       string       `url:"source,omitempty"`
+}
+
+// ReleaseDownloadAccessOptions specifies the optional parameters for books.myconfig.releaseDownloadAccess.
+type ReleaseDownloadAccessOptions struct {
+	Locale string `url:"locale,omitempty"`
+	Source string `url:"source,omitempty"`
+}
+
+// syncVolumeLicensesParams specifies the parameters sent to books.myconfig.syncVolumeLicenses.
+type syncVolumeLicensesParams struct {
+	Nonce   string `url:"nonce"`
+	Cpksver string `url:"cpksver"`
+	SyncVolumeLicensesOptions
+}
+
+// requestAccessParams specifies the parameters sent to books.myconfig.requestAccess.
+type requestAccessParams struct {
+	VolumeID string `url:"volumeId"`
+	Nonce    string `url:"nonce"`
+	Cpksver  string `url:"cpksver"`
+	RequestAccessOptions
+}
+
+// releaseDownloadAccessParams specifies the parameters sent to books.myconfig.releaseDownloadAccess.
+type releaseDownloadAccessParams struct {
+	VolumeIDs []string `url:"volumeIds"`
+	Cpksver   string   `url:"cpksver"`
+	ReleaseDownloadAccessOptions
+}
+
+// GetUserSettings will call the books.myconfig.getUserSettings API.
+// https://www.googleapis.com/books/v1/myconfig/getUserSettings
+func (c *GoogleConfigService) GetUserSettings() (*UserSettings, *Response, error) {
+	return c.GetUserSettingsContext(context.Background())
+}
+
+// GetUserSettingsContext is like GetUserSettings but takes a context.
+func (c *GoogleConfigService) GetUserSettingsContext(ctx context.Context) (*UserSettings, *Response, error) {
+	ctx = withOperation(ctx, Operation{Service: "Config", Name: "books.myconfig.getUserSettings"})
+
+	req, err := c.client.NewRequestWithContext(ctx, "GET", "myconfig/getUserSettings", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	settings := new(UserSettings)
+	resp, err := c.client.Do(req, settings)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return settings, resp, err
+}
+
+// UpdateUserSettings will call the books.myconfig.updateUserSettings API. Only the settings set in settings are
+// changed, the updated settings are returned.
+// https://www.googleapis.com/books/v1/myconfig/updateUserSettings
+func (c *GoogleConfigService) UpdateUserSettings(settings *UserSettings) (*UserSettings, *Response, error) {
+	return c.UpdateUserSettingsContext(context.Background(), settings)
+}
+
+// UpdateUserSettingsContext is like UpdateUserSettings but takes a context.
+func (c *GoogleConfigService) UpdateUserSettingsContext(ctx context.Context, settings *UserSettings) (*UserSettings, *Response, error) {
+	if settings == nil {
+		return nil, nil, errors.New("settings is a required field")
+	}
+
+	ctx = withOperation(ctx, Operation{Service: "Config", Name: "books.myconfig.updateUserSettings"})
+
+	req, err := c.client.NewRequestWithContext(ctx, "POST", "myconfig/updateUserSettings", settings)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated := new(UserSettings)
+	resp, err := c.client.Do(req, updated)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updated, resp, err
+}
+
+// SyncVolumeLicenses will call the books.myconfig.syncVolumeLicenses API to request downloaded content access
+// for the volumes of the user. nonce is a client generated value returned in the signed restrictions, cpksver is
+// the version of the client's content protection key store.
+// https://www.googleapis.com/books/v1/myconfig/syncVolumeLicenses
+func (c *GoogleConfigService) SyncVolumeLicenses(nonce string, cpksver string, opt *SyncVolumeLicensesOptions) ([]Volume, *Response, error) {
+	return c.SyncVolumeLicensesContext(context.Background(), nonce, cpksver, opt)
+}
+
+// SyncVolumeLicensesContext is like SyncVolumeLicenses but takes a context.
+func (c *GoogleConfigService) SyncVolumeLicensesContext(ctx context.Context, nonce string, cpksver string, opt *SyncVolumeLicensesOptions) ([]Volume, *Response, error) {
+	if nonce == "" {
+		return nil, nil, errors.New("nonce is a required field")
+	}
+	if cpksver == "" {
+		return nil, nil, errors.New("cpksver is a required field")
+	}
+
+	ctx = withOperation(ctx, Operation{Service: "Config", Name: "books.myconfig.syncVolumeLicenses"})
+
+	params := &syncVolumeLicensesParams{Nonce: nonce, Cpksver: cpksver}
+	if opt != nil {
+		params.SyncVolumeLicensesOptions = *opt
+	}
+
+	url, err := addOptions("myconfig/syncVolumeLicenses", params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(volumesRoot)
+	resp, err := c.client.Do(req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	resp.TotalItems = root.TotalItems
+
+	return root.Volumes, resp, err
+}
+
+// RequestAccess will call the books.myconfig.requestAccess API to request concurrent and download access to a
+// volume on this device.
+// https://www.googleapis.com/books/v1/myconfig/requestAccess
+func (c *GoogleConfigService) RequestAccess(volumeID string, nonce string, cpksver string, opt *RequestAccessOptions) (*RequestAccessData, *Response, error) {
+	return c.RequestAccessContext(context.Background(), volumeID, nonce, cpksver, opt)
+}
+
+// RequestAccessContext is like RequestAccess but takes a context.
+func (c *GoogleConfigService) RequestAccessContext(ctx context.Context, volumeID string, nonce string, cpksver string, opt *RequestAccessOptions) (*RequestAccessData, *Response, error) {
+	if volumeID == "" {
+		return nil, nil, errors.New("volumeID is a required field")
+	}
+	if nonce == "" {
+		return nil, nil, errors.New("nonce is a required field")
+	}
+	if cpksver == "" {
+		return nil, nil, errors.New("cpksver is a required field")
+	}
+
+	ctx = withOperation(ctx, Operation{Service: "Config", Name: "books.myconfig.requestAccess", VolumeID: volumeID})
+
+	params := &requestAccessParams{VolumeID: volumeID, Nonce: nonce, Cpksver: cpksver}
+	if opt != nil {
+		params.RequestAccessOptions = *opt
+	}
+
+	url, err := addOptions("myconfig/requestAccess", params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := new(RequestAccessData)
+	resp, err := c.client.Do(req, data)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return data, resp, err
+}
+
+// ReleaseDownloadAccess will call the books.myconfig.releaseDownloadAccess API to release the download licenses
+// of volumes on this device, so they can be downloaded on another one.
+// https://www.googleapis.com/books/v1/myconfig/releaseDownloadAccess
+func (c *GoogleConfigService) ReleaseDownloadAccess(volumeIDs []string, cpksver string, opt *ReleaseDownloadAccessOptions) (*DownloadAccesses, *Response, error) {
+	return c.ReleaseDownloadAccessContext(context.Background(), volumeIDs, cpksver, opt)
+}
+
+// ReleaseDownloadAccessContext is like ReleaseDownloadAccess but takes a context.
+func (c *GoogleConfigService) ReleaseDownloadAccessContext(ctx context.Context, volumeIDs []string, cpksver string, opt *ReleaseDownloadAccessOptions) (*DownloadAccesses, *Response, error) {
+	if len(volumeIDs) == 0 {
+		return nil, nil, errors.New("volumeIDs is a required field")
+	}
+	if cpksver == "" {
+		return nil, nil, errors.New("cpksver is a required field")
+	}
+
+	ctx = withOperation(ctx, Operation{Service: "Config", Name: "books.myconfig.releaseDownloadAccess"})
+
+	params := &releaseDownloadAccessParams{VolumeIDs: volumeIDs, Cpksver: cpksver}
+	if opt != nil {
+		params.ReleaseDownloadAccessOptions = *opt
+	}
+
+	url, err := addOptions("myconfig/releaseDownloadAccess", params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accesses := new(DownloadAccesses)
+	resp, err := c.client.Do(req, accesses)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return accesses, resp, err
+}
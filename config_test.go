@@ -0,0 +1,216 @@
+package books
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestConfigGetUserSettings(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/myconfig/getUserSettings", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"kind": "books#usersettings",
+			"notesExport": {"folderName": "Play Books Notes", "isEnabled": true},
+			"notification": {
+				"moreFromAuthors": {"opted_state": "OPTED_IN"},
+				"priceDrop": {"opted_state": "OPTED_OUT"}
+			}
+		}`)
+	})
+
+	settings, _, err := client.Config.GetUserSettings()
+	if err != nil {
+		t.Fatalf("GetUserSettings() returned an error: %v", err)
+	}
+
+	expected := &UserSettings{
+		Kind:        String("books#usersettings"),
+		NotesExport: &NotesExportSettings{FolderName: String("Play Books Notes"), IsEnabled: Bool(true)},
+		Notification: &NotificationSettings{
+			MoreFromAuthors: &NotificationSetting{OptedState: String(OptedStateIn)},
+			PriceDrop:       &NotificationSetting{OptedState: String(OptedStateOut)},
+		},
+	}
+	if !reflect.DeepEqual(settings, expected) {
+		t.Errorf("GetUserSettings() returned %+v, expected %+v", settings, expected)
+	}
+}
+
+func TestConfigUpdateUserSettings(t *testing.T) {
+	setup()
+	defer teardown()
+
+	input := &UserSettings{
+		Notification: &NotificationSettings{
+			RewardExpirations: &NotificationSetting{OptedState: String(OptedStateOut)},
+			MatchMyInterests:  &NotificationSetting{OptedState: String(OptedStateIn)},
+			MoreFromSeries:    &NotificationSetting{OptedState: String(OptedStateIn)},
+		},
+	}
+
+	mux.HandleFunc("/myconfig/updateUserSettings", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		v := new(UserSettings)
+		if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+			t.Fatalf("decode request body: %v", err)
+		}
+		if !reflect.DeepEqual(v, input) {
+			t.Errorf("Request body = %+v, expected %+v", v, input)
+		}
+		fmt.Fprint(w, `{"kind":"books#usersettings","notesExport":{"isEnabled":false}}`)
+	})
+
+	settings, _, err := client.Config.UpdateUserSettings(input)
+	if err != nil {
+		t.Fatalf("UpdateUserSettings() returned an error: %v", err)
+	}
+
+	expected := &UserSettings{Kind: String("books#usersettings"), NotesExport: &NotesExportSettings{IsEnabled: Bool(false)}}
+	if !reflect.DeepEqual(settings, expected) {
+		t.Errorf("UpdateUserSettings() returned %+v, expected %+v", settings, expected)
+	}
+}
+
+func TestConfigUpdateUserSettings_requiredFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	if _, _, err := client.Config.UpdateUserSettings(nil); err == nil {
+		t.Error("UpdateUserSettings() without settings returned no error")
+	}
+}
+
+func TestConfigSyncVolumeLicenses(t *testing.T) {
+	setup()
+	defer teardown()
+
+	golden := loadFixture(t, "myconfig_sync_volume_licenses.json")
+	mux.HandleFunc("/myconfig/syncVolumeLicenses", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		q := r.URL.Query()
+		if got, want := q["features"], []string{FeatureRentals}; !reflect.DeepEqual(got, want) {
+			t.Errorf("features = %v, expected %v", got, want)
+		}
+		if got, want := q["volumeIds"], []string{"VN2jCgAAAEAJ", "SJHvCgAAQBAJ"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("volumeIds = %v, expected %v", got, want)
+		}
+		for k, want := range map[string]string{"nonce": "f1a9c3", "cpksver": "1.0", "locale": "en-US", "source": "ge-reader-app"} {
+			if got := q.Get(k); got != want {
+				t.Errorf("%s = %q, expected %q", k, got, want)
+			}
+		}
+		w.Write(golden)
+	})
+
+	opts := &SyncVolumeLicensesOptions{
+		VolumeIDs: []string{"VN2jCgAAAEAJ", "SJHvCgAAQBAJ"},
+		Features:  []string{FeatureRentals},
+		Locale:    "en-US",
+		Source:    "ge-reader-app",
+	}
+	volumes, resp, err := client.Config.SyncVolumeLicenses("f1a9c3", "1.0", opts)
+	if err != nil {
+		t.Fatalf("SyncVolumeLicenses() returned an error: %v", err)
+	}
+
+	var root struct {
+		Items json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(golden, &root); err != nil {
+		t.Fatalf("json.Unmarshal(): %v", err)
+	}
+	testJSONRoundTrip(t, root.Items, volumes)
+
+	if resp.TotalItems != 1 {
+		t.Errorf("TotalItems = %d, expected 1", resp.TotalItems)
+	}
+	access := volumes[0].AccessInfo.DownloadAccess
+	if *access.Nonce != "f1a9c3" || *access.MaxDownloadDevices != 6 || *access.Signature == "" {
+		t.Errorf("DownloadAccess = %+v", access)
+	}
+}
+
+func TestConfigRequestAccess(t *testing.T) {
+	setup()
+	defer teardown()
+
+	golden := loadFixture(t, "myconfig_request_access.json")
+	mux.HandleFunc("/myconfig/requestAccess", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{
+			"volumeId":     "VN2jCgAAAEAJ",
+			"nonce":        "f1a9c3",
+			"cpksver":      "1.0",
+			"licenseTypes": "BOTH",
+			"locale":       "en-US",
+			"source":       "ge-reader-app",
+		})
+		w.Write(golden)
+	})
+
+	opts := &RequestAccessOptions{LicenseTypes: LicenseTypesBoth, Locale: "en-US", Source: "ge-reader-app"}
+	data, _, err := client.Config.RequestAccess("VN2jCgAAAEAJ", "f1a9c3", "1.0", opts)
+	if err != nil {
+		t.Fatalf("RequestAccess() returned an error: %v", err)
+	}
+
+	testJSONRoundTrip(t, golden, data)
+
+	if got := data.ConcurrentAccess; *got.MaxConcurrentDevices != 2 || *got.TimeWindowSeconds != 3600 || *got.Nonce != "f1a9c3" {
+		t.Errorf("ConcurrentAccess = %+v", got)
+	}
+	if got := data.DownloadAccess; *got.DeviceAllowed || *got.Message != "Download limit reached." {
+		t.Errorf("DownloadAccess = %+v", got)
+	}
+}
+
+func TestConfigReleaseDownloadAccess(t *testing.T) {
+	setup()
+	defer teardown()
+
+	golden := loadFixture(t, "myconfig_release_download_access.json")
+	mux.HandleFunc("/myconfig/releaseDownloadAccess", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		q := r.URL.Query()
+		if got, want := q["volumeIds"], []string{"VN2jCgAAAEAJ", "SJHvCgAAQBAJ"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("volumeIds = %v, expected %v", got, want)
+		}
+		if got := q.Get("cpksver"); got != "1.0" {
+			t.Errorf("cpksver = %q, expected 1.0", got)
+		}
+		w.Write(golden)
+	})
+
+	accesses, _, err := client.Config.ReleaseDownloadAccess([]string{"VN2jCgAAAEAJ", "SJHvCgAAQBAJ"}, "1.0", nil)
+	if err != nil {
+		t.Fatalf("ReleaseDownloadAccess() returned an error: %v", err)
+	}
+
+	testJSONRoundTrip(t, golden, accesses)
+
+	if len(accesses.DownloadAccessList) != 2 || *accesses.DownloadAccessList[1].Restricted {
+		t.Errorf("DownloadAccessList = %+v", accesses.DownloadAccessList)
+	}
+}
+
+func TestConfigLicenses_requiredFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	if _, _, err := client.Config.SyncVolumeLicenses("", "1.0", nil); err == nil {
+		t.Error("SyncVolumeLicenses() without nonce returned no error")
+	}
+	if _, _, err := client.Config.RequestAccess("VN2jCgAAAEAJ", "f1a9c3", "", nil); err == nil {
+		t.Error("RequestAccess() without cpksver returned no error")
+	}
+	if _, _, err := client.Config.ReleaseDownloadAccess(nil, "1.0", nil); err == nil {
+		t.Error("ReleaseDownloadAccess() without volumeIDs returned no error")
+	}
+}
@@ -0,0 +1,212 @@
+package books
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DecodeError reports a response body that could not be decoded into the expected type.
+type DecodeError struct {
+	// Path is the dotted JSON path of the value that failed to decode, for example
+	// "items.0.volumeInfo.pageCount". It is empty for syntax errors.
+	Path string
+
+	// Offset is the byte offset in the response body where decoding failed.
+	Offset int64
+
+	// Err is the underlying encoding/json error, or an *UnknownFieldsError in strict mode.
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("books: decoding response at offset %d: %v", e.Offset, e.Err)
+	}
+	return fmt.Sprintf("books: decoding response at %s (offset %d): %v", e.Path, e.Offset, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// UnknownFieldsError lists the fields of a response that are not modeled by the type it was decoded into. It is
+// only reported in strict decoding mode.
+type UnknownFieldsError struct {
+	// Fields are the dotted JSON paths of the unknown fields, sorted.
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("unknown fields %s", strings.Join(e.Fields, ", "))
+}
+
+// SetStrictDecoding is a client option for failing requests whose response has fields that the decoded type
+// does not model. The error is a *DecodeError wrapping an *UnknownFieldsError. It helps noticing when the API
+// adds fields, and should not be enabled in production.
+func SetStrictDecoding(strict bool) ClientOpt {
+	return func(c *Client) error {
+		c.strictDecoding = strict
+		return nil
+	}
+}
+
+// decodeJSON decodes the JSON document read from r into v. An empty document is not an error.
+func decodeJSON(r io.Reader, v interface{}, strict bool) error {
+	// In strict mode the document is kept, to find every unknown field once the decoder stopped at the first.
+	var buf bytes.Buffer
+	if strict {
+		r = io.TeeReader(r, &buf)
+	}
+
+	counter := &countingReader{r: r}
+	dec := json.NewDecoder(counter)
+	if strict {
+		dec.DisallowUnknownFields()
+	}
+
+	err := dec.Decode(v)
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *json.UnmarshalTypeError:
+		return &DecodeError{Path: e.Field, Offset: e.Offset, Err: err}
+	case *json.SyntaxError:
+		return &DecodeError{Offset: e.Offset, Err: err}
+	}
+
+	if err == io.EOF {
+		return nil
+	}
+
+	if strict && strings.HasPrefix(err.Error(), "json: unknown field ") {
+		offset := dec.InputOffset()
+		io.Copy(ioutil.Discard, r)
+
+		var doc interface{}
+		if json.Unmarshal(buf.Bytes(), &doc) == nil {
+			if fields := unknownFields(doc, reflect.TypeOf(v), "", nil); len(fields) > 0 {
+				return &DecodeError{Path: fields[0], Offset: offset, Err: &UnknownFieldsError{Fields: fields}}
+			}
+		}
+		return &DecodeError{Offset: offset, Err: err}
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return &DecodeError{Offset: counter.n, Err: err}
+	}
+	return err
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// unknownFields returns the sorted paths of the object keys of doc that t has no field for. Paths use the
+// format of json.UnmarshalTypeError.Field, array elements are designated by their index.
+func unknownFields(doc interface{}, t reflect.Type, path string, found map[string]bool) []string {
+	if found == nil {
+		found = make(map[string]bool)
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		return sortedKeys(found)
+	}
+
+	switch d := doc.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			for key, value := range d {
+				p := key
+				if path != "" {
+					p = path + "." + key
+				}
+
+				f, ok := jsonField(t, key)
+				if !ok {
+					found[p] = true
+					continue
+				}
+				unknownFields(value, f.Type, p, found)
+			}
+		case reflect.Map:
+			for key, value := range d {
+				p := key
+				if path != "" {
+					p = path + "." + key
+				}
+				unknownFields(value, t.Elem(), p, found)
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, value := range d {
+				p := strconv.Itoa(i)
+				if path != "" {
+					p = path + "." + p
+				}
+				unknownFields(value, t.Elem(), p, found)
+			}
+		}
+	}
+
+	return sortedKeys(found)
+}
+
+// jsonField returns the field of struct t that encoding/json decodes key into.
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	var fold reflect.StructField
+	folded := false
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		if name == key {
+			return f, true
+		}
+		if !folded && strings.EqualFold(name, key) {
+			fold, folded = f, true
+		}
+	}
+
+	return fold, folded
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,136 @@
+package books
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSON_typeError(t *testing.T) {
+	body := `{"totalItems":1,"items":[{"id":"a","volumeInfo":{"pageCount":"many"}}]}`
+
+	err := decodeJSON(strings.NewReader(body), new(volumesRoot), false)
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("decodeJSON() error = %#v, expected *DecodeError", err)
+	}
+	// Older versions of encoding/json only report the innermost struct fields.
+	if got, expected := decodeErr.Path, "volumeInfo.pageCount"; !strings.HasSuffix(got, expected) {
+		t.Errorf("Path = %q, expected it to end with %q", got, expected)
+	}
+	if got, expected := decodeErr.Offset, int64(strings.Index(body, `"many"`)+len(`"many"`)); got != expected {
+		t.Errorf("Offset = %d, expected %d", got, expected)
+	}
+}
+
+func TestDecodeJSON_syntaxError(t *testing.T) {
+	cases := []struct {
+		body   string
+		offset int64
+	}{
+		{`{"items":[}`, 11},
+		{`{"items":[{"id":"a"}`, 20},
+	}
+
+	for _, c := range cases {
+		err := decodeJSON(strings.NewReader(c.body), new(volumesRoot), false)
+
+		var decodeErr *DecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Errorf("decodeJSON(%q) error = %#v, expected *DecodeError", c.body, err)
+			continue
+		}
+		if decodeErr.Offset != c.offset {
+			t.Errorf("decodeJSON(%q) Offset = %d, expected %d", c.body, decodeErr.Offset, c.offset)
+		}
+	}
+}
+
+func TestDecodeJSON_empty(t *testing.T) {
+	if err := decodeJSON(strings.NewReader(""), new(volumesRoot), true); err != nil {
+		t.Errorf("decodeJSON() returned an error for an empty body: %v", err)
+	}
+}
+
+func TestDecodeJSON_strict(t *testing.T) {
+	body := `{"kind":"books#volumes","totalItems":1,"extra":1,"items":[
+		{"id":"a","recommendedInfo":{"explanation":"x"},"volumeInfo":{"title":"t","panelizationSummary":{}}},
+		{"id":"b","volumeInfo":{"panelizationSummary":{}}}]}`
+
+	if err := decodeJSON(strings.NewReader(body), new(volumesRoot), false); err != nil {
+		t.Errorf("decodeJSON() returned an error outside of strict mode: %v", err)
+	}
+
+	err := decodeJSON(strings.NewReader(body), new(volumesRoot), true)
+
+	var unknown *UnknownFieldsError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("decodeJSON() error = %#v, expected *UnknownFieldsError", err)
+	}
+	expected := []string{"extra", "items.0.recommendedInfo", "items.0.volumeInfo.panelizationSummary", "items.1.volumeInfo.panelizationSummary"}
+	if !reflect.DeepEqual(unknown.Fields, expected) {
+		t.Errorf("Fields = %v, expected %v", unknown.Fields, expected)
+	}
+	if decodeErr := err.(*DecodeError); decodeErr.Offset == 0 {
+		t.Error("Offset = 0, expected the offset of the first unknown field")
+	}
+}
+
+func TestDecodeJSON_strictFixtures(t *testing.T) {
+	cases := []struct {
+		fixture string
+		v       interface{}
+	}{
+		{"annotations_list.json", new(annotationRoot)},
+		{"volumes_get.json", new(Volume)},
+		{"volumes_search.json", new(volumesRoot)},
+		{"shelves_list.json", new(shelvesRoot)},
+	}
+
+	for _, c := range cases {
+		golden := loadFixture(t, c.fixture)
+		if err := decodeJSON(strings.NewReader(string(golden)), c.v, true); err != nil {
+			t.Errorf("%s: %v", c.fixture, err)
+		}
+	}
+}
+
+func TestDo_decodeError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/mylibrary/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items":[{"id":"seven"}]}`)
+	})
+
+	_, resp, err := client.Shelves.List(nil)
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || !strings.HasSuffix(decodeErr.Path, "id") {
+		t.Errorf("List() error = %v, expected a *DecodeError at items.0.id", err)
+	}
+	if resp == nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("List() response = %v, expected the 200 response", resp)
+	}
+}
+
+func TestDo_strictDecoding(t *testing.T) {
+	setup()
+	defer teardown()
+	SetStrictDecoding(true)(client)
+
+	mux.HandleFunc("/mylibrary/annotations", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"kind":"books#annotations","items":[{"id":"1","newField":true}]}`)
+	})
+
+	_, _, err := client.Annotations.List(nil)
+
+	var unknown *UnknownFieldsError
+	if !errors.As(err, &unknown) || !reflect.DeepEqual(unknown.Fields, []string{"items.0.newField"}) {
+		t.Errorf("List() error = %v, expected unknown field items.0.newField", err)
+	}
+}
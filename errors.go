@@ -0,0 +1,99 @@
+package books
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Sentinel errors that API errors can be matched against with errors.Is, for example
+//
+//	if errors.Is(err, books.ErrNotFound) {
+//		// skip the missing volume
+//	}
+//
+// An *ErrorResponse matches them by its HTTP status, canonical status and ErrorItem reasons.
+var (
+	// ErrNotFound matches errors for resources that do not exist.
+	ErrNotFound = errors.New("books: not found")
+
+	// ErrRateLimited matches errors for requests sent faster than the rate limits allow. They can be retried
+	// after waiting.
+	ErrRateLimited = errors.New("books: rate limited")
+
+	// ErrQuotaExceeded matches errors for requests exceeding a daily or project quota. Retrying does not help
+	// before the quota resets.
+	ErrQuotaExceeded = errors.New("books: quota exceeded")
+
+	// ErrAuth matches errors for requests with missing, invalid or expired credentials. The token should be
+	// refreshed before retrying.
+	ErrAuth = errors.New("books: authentication error")
+
+	// ErrPermissionDenied matches errors for authenticated requests not allowed to access the resource.
+	ErrPermissionDenied = errors.New("books: permission denied")
+)
+
+// Reason returns the reason of the first error item, if any.
+func (r *ErrorResponse) Reason() string {
+	if len(r.CustomError.Errors) == 0 {
+		return ""
+	}
+	return r.CustomError.Errors[0].Reason
+}
+
+// Is reports whether r matches target, one of the sentinel errors of this package.
+func (r *ErrorResponse) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return r.status() == http.StatusNotFound || r.CustomError.Status == "NOT_FOUND" || r.hasReason("notFound")
+	case ErrRateLimited:
+		return r.status() == http.StatusTooManyRequests ||
+			r.hasReason("rateLimitExceeded", "userRateLimitExceeded")
+	case ErrQuotaExceeded:
+		return r.hasReason("quotaExceeded", "dailyLimitExceeded", "dailyLimitExceededUnreg")
+	case ErrAuth:
+		return r.status() == http.StatusUnauthorized || r.CustomError.Status == "UNAUTHENTICATED" ||
+			r.hasReason("authError", "unauthorized")
+	case ErrPermissionDenied:
+		if r.Is(ErrRateLimited) || r.Is(ErrQuotaExceeded) {
+			return false
+		}
+		return r.status() == http.StatusForbidden || r.CustomError.Status == "PERMISSION_DENIED" ||
+			r.hasReason("forbidden", "insufficientPermissions", "accessNotConfigured")
+	}
+	return false
+}
+
+// status returns the HTTP status code of the error.
+func (r *ErrorResponse) status() int {
+	if r.Response != nil {
+		return r.Response.StatusCode
+	}
+	return r.CustomError.Code
+}
+
+// hasReason reports whether any error item has one of reasons.
+func (r *ErrorResponse) hasReason(reasons ...string) bool {
+	for _, item := range r.CustomError.Errors {
+		for _, reason := range reasons {
+			if item.Reason == reason {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether err is an API error for a resource that does not exist.
+func IsNotFound(err error) bool { return errors.Is(err, ErrNotFound) }
+
+// IsRateLimited reports whether err is an API error for exceeding a rate limit.
+func IsRateLimited(err error) bool { return errors.Is(err, ErrRateLimited) }
+
+// IsQuotaExceeded reports whether err is an API error for exceeding a quota.
+func IsQuotaExceeded(err error) bool { return errors.Is(err, ErrQuotaExceeded) }
+
+// IsAuthError reports whether err is an API error for missing, invalid or expired credentials.
+func IsAuthError(err error) bool { return errors.Is(err, ErrAuth) }
+
+// IsPermissionDenied reports whether err is an API error for a request not allowed to access a resource.
+func IsPermissionDenied(err error) bool { return errors.Is(err, ErrPermissionDenied) }
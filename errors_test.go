@@ -0,0 +1,176 @@
+package books
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func newErrorResponse(t *testing.T, status int, body string) *ErrorResponse {
+	u, _ := url.Parse("https://www.googleapis.com/books/v1/volumes/x?key=secret")
+	res := &http.Response{
+		Request:    &http.Request{Method: "GET", URL: u},
+		StatusCode: status,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+
+	err, ok := CheckResponse(res).(*ErrorResponse)
+	if !ok {
+		t.Fatalf("CheckResponse() did not return an *ErrorResponse")
+	}
+	return err
+}
+
+func TestCheckResponse_googleErrorEnvelope(t *testing.T) {
+	err := newErrorResponse(t, http.StatusUnauthorized, `{"error":{"code":401,"message":"Login Required.","status":"UNAUTHENTICATED",
+		"errors":[{"domain":"global","reason":"required","message":"Login Required.","locationType":"header","location":"Authorization",
+		"extendedHelp":"https://developers.google.com/books/docs/v1/using#auth"}]}}`)
+
+	expected := Error{
+		Code:    401,
+		Message: "Login Required.",
+		Status:  "UNAUTHENTICATED",
+		Errors: []ErrorItem{{
+			Domain:       "global",
+			Reason:       "required",
+			Message:      "Login Required.",
+			Location:     "Authorization",
+			LocationType: "header",
+			ExtendedHelp: "https://developers.google.com/books/docs/v1/using#auth",
+		}},
+	}
+	if !reflect.DeepEqual(err.CustomError, expected) {
+		t.Errorf("CustomError = %+v, expected %+v", err.CustomError, expected)
+	}
+}
+
+func TestCheckResponse_notJSON(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		body   string
+		check  func(error) bool
+	}{
+		{"plain text 404", http.StatusNotFound, "404 page not found\n", IsNotFound},
+		{"HTML 503", http.StatusServiceUnavailable, "<html><body><h1>503 Service Unavailable</h1></body></html>", func(err error) bool {
+			var errResp *ErrorResponse
+			return errors.As(err, &errResp) && errResp.status() == http.StatusServiceUnavailable && !IsNotFound(err)
+		}},
+	}
+
+	for _, tc := range cases {
+		err := newErrorResponse(t, tc.status, tc.body)
+		if got := err.CustomError.Code; got != tc.status {
+			t.Errorf("%s: Code = %d, expected %d", tc.name, got, tc.status)
+		}
+		if got := err.CustomError.Message; got != strings.TrimSpace(tc.body) {
+			t.Errorf("%s: Message = %q, expected the body", tc.name, got)
+		}
+		if !tc.check(err) {
+			t.Errorf("%s: %v was not classified by its status", tc.name, err)
+		}
+	}
+
+	if err := newErrorResponse(t, http.StatusNotFound, "not found"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("errors.Is(%v, ErrNotFound) = false", err)
+	}
+}
+
+func TestCheckResponse_notJSONTruncated(t *testing.T) {
+	err := newErrorResponse(t, http.StatusBadGateway, strings.Repeat("x", 2*maxErrorBodyMessage))
+	if got := len(err.CustomError.Message); got != maxErrorBodyMessage+len("...") {
+		t.Errorf("len(Message) = %d, expected the body truncated", got)
+	}
+}
+
+func TestCheckResponse_notJSONTruncatedRune(t *testing.T) {
+	// The three bytes of "€" straddle the truncation limit.
+	body := strings.Repeat("x", maxErrorBodyMessage-1) + "€" + strings.Repeat("x", 10)
+	err := newErrorResponse(t, http.StatusBadGateway, body)
+
+	msg := err.CustomError.Message
+	if !utf8.ValidString(msg) {
+		t.Errorf("Message is not valid UTF-8: %q", msg[len(msg)-8:])
+	}
+	if expected := strings.Repeat("x", maxErrorBodyMessage-1) + "..."; msg != expected {
+		t.Errorf("Message ends with %q, expected the body truncated before the rune", msg[len(msg)-8:])
+	}
+}
+
+func TestErrorResponse_classification(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   int
+		body     string
+		expected error
+	}{
+		{"not found", 404, `{"error":{"code":404,"message":"The volume ID could not be found.","errors":[{"reason":"notFound"}]}}`, ErrNotFound},
+		{"not found without body", 404, ``, ErrNotFound},
+		{"rate limit", 403, `{"error":{"code":403,"message":"Rate Limit Exceeded","errors":[{"domain":"usageLimits","reason":"rateLimitExceeded"}]}}`, ErrRateLimited},
+		{"user rate limit", 403, `{"error":{"code":403,"message":"User Rate Limit Exceeded","errors":[{"domain":"usageLimits","reason":"userRateLimitExceeded"}]}}`, ErrRateLimited},
+		{"too many requests", 429, ``, ErrRateLimited},
+		{"daily limit", 403, `{"error":{"code":403,"message":"Daily Limit Exceeded","errors":[{"domain":"usageLimits","reason":"dailyLimitExceeded"}]}}`, ErrQuotaExceeded},
+		{"quota", 403, `{"error":{"code":403,"message":"Quota Exceeded","errors":[{"domain":"usageLimits","reason":"quotaExceeded"}]}}`, ErrQuotaExceeded},
+		{"invalid credentials", 401, `{"error":{"code":401,"message":"Invalid Credentials","errors":[{"reason":"authError","location":"Authorization"}]}}`, ErrAuth},
+		{"forbidden", 403, `{"error":{"code":403,"message":"Forbidden","status":"PERMISSION_DENIED","errors":[{"reason":"forbidden"}]}}`, ErrPermissionDenied},
+	}
+
+	sentinels := []error{ErrNotFound, ErrRateLimited, ErrQuotaExceeded, ErrAuth, ErrPermissionDenied}
+
+	for _, c := range cases {
+		err := newErrorResponse(t, c.status, c.body)
+		for _, s := range sentinels {
+			if got := errors.Is(err, s); got != (s == c.expected) {
+				t.Errorf("%q errors.Is(err, %v) = %v, expected %v", c.name, s, got, s == c.expected)
+			}
+		}
+	}
+}
+
+func TestErrorResponse_predicates(t *testing.T) {
+	notFound := fmt.Errorf("enriching volume: %w", newErrorResponse(t, 404, ``))
+	rateLimited := newErrorResponse(t, 429, ``)
+	quota := newErrorResponse(t, 403, `{"error":{"code":403,"errors":[{"reason":"dailyLimitExceeded"}]}}`)
+	auth := newErrorResponse(t, 401, ``)
+	denied := newErrorResponse(t, 403, ``)
+
+	if !IsNotFound(notFound) || IsNotFound(rateLimited) {
+		t.Error("IsNotFound() misclassified errors")
+	}
+	if !IsRateLimited(rateLimited) || IsRateLimited(quota) {
+		t.Error("IsRateLimited() misclassified errors")
+	}
+	if !IsQuotaExceeded(quota) || IsQuotaExceeded(denied) {
+		t.Error("IsQuotaExceeded() misclassified errors")
+	}
+	if !IsAuthError(auth) || IsAuthError(denied) {
+		t.Error("IsAuthError() misclassified errors")
+	}
+	if !IsPermissionDenied(denied) || IsPermissionDenied(quota) {
+		t.Error("IsPermissionDenied() misclassified errors")
+	}
+	if IsNotFound(errors.New("not found")) || IsNotFound(nil) {
+		t.Error("IsNotFound() matched an error that is not an API error")
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As(notFound, &errResp) || errResp.Response.StatusCode != 404 {
+		t.Errorf("errors.As() did not find the *ErrorResponse in %v", notFound)
+	}
+}
+
+func TestErrorResponse_errorString(t *testing.T) {
+	err := newErrorResponse(t, 404, `{"error":{"code":404,"message":"The volume ID could not be found.","errors":[{"reason":"notFound"}]}}`)
+
+	got := err.Error()
+	expected := "GET https://www.googleapis.com/books/v1/volumes/x?key=REDACTED: 404 The volume ID could not be found. (notFound)"
+	if got != expected {
+		t.Errorf("Error() = %q, expected %q", got, expected)
+	}
+}
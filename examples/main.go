@@ -37,7 +37,7 @@ func exampleVolumesList(c *books.Client) {
 		MaxResults: 1,
 	}
 
-	volumes, _, err := c.Volumes.List("1", opts)
+	volumes, _, err := c.Volumes.List(books.ShelfPurchased, opts)
 	if err != nil {
 		log.Fatalf("error in List(): %v", err)
 	}
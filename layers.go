@@ -0,0 +1,171 @@
+package books
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// LayersService defines the behavior required by types that want to implement a new Layer type.
+// The books.layers.volumeAnnotations and books.layers.annotationData APIs are sub-services of the layers API. As
+// LayersService is an interface, they are exposed by the VolumeAnnotations and AnnotationData methods rather than
+// as fields, and are created once with the client.
+type LayersService interface {
+	List(string, *LayersListOptions) ([]LayerSummary, *Response, error)
+	ListContext(context.Context, string, *LayersListOptions) ([]LayerSummary, *Response, error)
+	Get(string, string, *LayersGetOptions) (*LayerSummary, *Response, error)
+	GetContext(context.Context, string, string, *LayersGetOptions) (*LayerSummary, *Response, error)
+	VolumeAnnotations() VolumeAnnotationsService
+	AnnotationData() AnnotationDataService
+}
+
+// GoogleLayersService implements the LayersService interface.
+type GoogleLayersService struct {
+	client *Client
+
+	volumeAnnotations VolumeAnnotationsService
+	annotationData    AnnotationDataService
+}
+
+// newGoogleLayersService returns the layers service of c, with its sub-services.
+func newGoogleLayersService(c *Client) *GoogleLayersService {
+	return &GoogleLayersService{
+		client:            c,
+		volumeAnnotations: &GoogleVolumeAnnotationsService{client: c},
+		annotationData:    &GoogleAnnotationDataService{client: c},
+	}
+}
+
+// LayerSummary represents a Google Book Layersummary resource, an annotation layer of a volume.
+// https://developers.google.com/books/docs/v1/reference/layers#resource
+type LayerSummary struct {
+	Kind                     *string    `json:"kind,omitempty"`
+	ID                       *string    `json:"id,omitempty"`
+	VolumeID                 *string    `json:"volumeId,omitempty"`
+	LayerID                  *string    `json:"layerId,omitempty"`
+	AnnotationTypes          []string   `json:"annotationTypes,omitempty"`
+	AnnotationCount          *int       `json:"annotationCount,omitempty"`
+	DataCount                *int       `json:"dataCount,omitempty"`
+	AnnotationsLink          *string    `json:"annotationsLink,omitempty"`
+	AnnotationsDataLink      *string    `json:"annotationsDataLink,omitempty"`
+	ContentVersion           *string    `json:"contentVersion,omitempty"`
+	VolumeAnnotationsVersion *string    `json:"volumeAnnotationsVersion,omitempty"`
+	SelfLink                 *string    `json:"selfLink,omitempty"`
+	Updated                  *time.Time `json:"updated,omitempty"`
+}
+
+type layersRoot struct {
+	Kind          *string        `json:"kind,omitempty"`
+	TotalItems    *int           `json:"totalItems,omitempty"`
+	NextPageToken *string        `json:"nextPageToken,omitempty"`
+	Layers        []LayerSummary `json:"items,omitempty"`
+}
+
+func (r *layersRoot) itemCount() int { return len(r.Layers) }
+
+// LayersListOptions specifies the optional parameters for books.layers.list.
+type LayersListOptions struct {
+	ContentVersion string `url:"contentVersion,omitempty"`
+	MaxResults     int    `url:"maxResults,omitempty"`
+	PageToken      string `url:"pageToken,omitempty"`
+	Source         string `url:"source,omitempty"`
+}
+
+// LayersGetOptions specifies the optional parameters for books.layers.get.
+type LayersGetOptions struct {
+	ContentVersion string `url:"contentVersion,omitempty"`
+	Source         string `url:"source,omitempty"`
+}
+
+// List will call the books.layers.list API to list the annotation layers of a volume.
+// https://www.googleapis.com/books/v1/volumes/{volumeId}/layersummary
+func (l *GoogleLayersService) List(volumeID string, opt *LayersListOptions) ([]LayerSummary, *Response, error) {
+	return l.ListContext(context.Background(), volumeID, opt)
+}
+
+// ListContext is like List but takes a context.
+func (l *GoogleLayersService) ListContext(ctx context.Context, volumeID string, opt *LayersListOptions) ([]LayerSummary, *Response, error) {
+	if volumeID == "" {
+		return nil, nil, errors.New("volumeID is a required field")
+	}
+
+	op := Operation{Service: "Layers", Name: "books.layers.list", VolumeID: volumeID}
+	if opt != nil {
+		op.PageToken = opt.PageToken
+	}
+	ctx = withOperation(ctx, op)
+
+	url := fmt.Sprintf("volumes/%s/layersummary", volumeID)
+	url, err := addOptions(url, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := l.client.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(layersRoot)
+	resp, err := l.client.Do(req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if n := root.NextPageToken; n != nil {
+		resp.NextPageToken = *n
+	}
+	if t := root.TotalItems; t != nil {
+		resp.TotalItems = *t
+	}
+
+	return root.Layers, resp, err
+}
+
+// Get will call the books.layers.get API to get a single annotation layer of a volume.
+// https://www.googleapis.com/books/v1/volumes/{volumeId}/layersummary/{summaryId}
+func (l *GoogleLayersService) Get(volumeID string, summaryID string, opt *LayersGetOptions) (*LayerSummary, *Response, error) {
+	return l.GetContext(context.Background(), volumeID, summaryID, opt)
+}
+
+// GetContext is like Get but takes a context.
+func (l *GoogleLayersService) GetContext(ctx context.Context, volumeID string, summaryID string, opt *LayersGetOptions) (*LayerSummary, *Response, error) {
+	if volumeID == "" {
+		return nil, nil, errors.New("volumeID is a required field")
+	}
+	if summaryID == "" {
+		return nil, nil, errors.New("summaryID is a required field")
+	}
+
+	ctx = withOperation(ctx, Operation{Service: "Layers", Name: "books.layers.get", VolumeID: volumeID})
+
+	url := fmt.Sprintf("volumes/%s/layersummary/%s", volumeID, summaryID)
+	url, err := addOptions(url, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := l.client.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	layer := new(LayerSummary)
+	resp, err := l.client.Do(req, layer)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return layer, resp, err
+}
+
+// VolumeAnnotations returns the service for the annotations of a layer, books.layers.volumeAnnotations.
+func (l *GoogleLayersService) VolumeAnnotations() VolumeAnnotationsService {
+	return l.volumeAnnotations
+}
+
+// AnnotationData returns the service for the data of the annotations of a layer, books.layers.annotationData.
+func (l *GoogleLayersService) AnnotationData() AnnotationDataService {
+	return l.annotationData
+}
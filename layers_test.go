@@ -0,0 +1,110 @@
+package books
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLayersList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/volumes/VN2jCgAAAEAJ/layersummary", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"contentVersion": "full-1.0.0", "maxResults": "2", "pageToken": "CgRnZW8"})
+		fmt.Fprint(w, `{"totalItems":3,"items":[{"layerId":"notes","annotationCount":4}]}`)
+	})
+
+	opts := &LayersListOptions{ContentVersion: "full-1.0.0", MaxResults: 2, PageToken: "CgRnZW8"}
+	layers, resp, err := client.Layers.List("VN2jCgAAAEAJ", opts)
+	if err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+
+	expected := []LayerSummary{{LayerID: String("notes"), AnnotationCount: Int(4)}}
+	if !reflect.DeepEqual(layers, expected) {
+		t.Errorf("List() returned %+v, expected %+v", layers, expected)
+	}
+	if resp.TotalItems != 3 || resp.NextPageToken != "" {
+		t.Errorf("TotalItems = %d, NextPageToken = %q, expected 3 and none", resp.TotalItems, resp.NextPageToken)
+	}
+}
+
+func TestLayersList_golden(t *testing.T) {
+	setup()
+	defer teardown()
+
+	golden := loadFixture(t, "layers_list.json")
+	mux.HandleFunc("/volumes/VN2jCgAAAEAJ/layersummary", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(golden)
+	})
+
+	layers, resp, err := client.Layers.List("VN2jCgAAAEAJ", nil)
+	if err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+
+	var root struct {
+		Items json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(golden, &root); err != nil {
+		t.Fatalf("json.Unmarshal(): %v", err)
+	}
+	testJSONRoundTrip(t, root.Items, layers)
+
+	if got, expected := resp.NextPageToken, "CgRnZW8"; got != expected {
+		t.Errorf("NextPageToken = %q, expected %q", got, expected)
+	}
+
+	updated := time.Date(2016, 2, 11, 9, 27, 3, 851000000, time.UTC)
+	if !layers[0].Updated.Equal(updated) {
+		t.Errorf("Updated = %v, expected %v", layers[0].Updated, updated)
+	}
+	if !reflect.DeepEqual(layers[1].AnnotationTypes, []string{"geo"}) {
+		t.Errorf("AnnotationTypes = %v, expected [geo]", layers[1].AnnotationTypes)
+	}
+}
+
+func TestLayersGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/volumes/VN2jCgAAAEAJ/layersummary/geo", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"contentVersion": "full-1.0.0"})
+		fmt.Fprint(w, `{"id":"geo","layerId":"geo","dataCount":9,"volumeAnnotationsVersion":"2"}`)
+	})
+
+	layer, _, err := client.Layers.Get("VN2jCgAAAEAJ", "geo", &LayersGetOptions{ContentVersion: "full-1.0.0"})
+	if err != nil {
+		t.Fatalf("Get() returned an error: %v", err)
+	}
+
+	expected := &LayerSummary{ID: String("geo"), LayerID: String("geo"), DataCount: Int(9), VolumeAnnotationsVersion: String("2")}
+	if !reflect.DeepEqual(layer, expected) {
+		t.Errorf("Get() returned %+v, expected %+v", layer, expected)
+	}
+}
+
+func TestLayers_requiredFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	if _, _, err := client.Layers.List("", nil); err == nil {
+		t.Error("List() without volumeID returned no error")
+	}
+	if _, _, err := client.Layers.Get("VN2jCgAAAEAJ", "", nil); err == nil {
+		t.Error("Get() without summaryID returned no error")
+	}
+}
+
+func TestLayers_subServices(t *testing.T) {
+	c := NewClient(nil)
+	if c.Layers.VolumeAnnotations() != c.Layers.VolumeAnnotations() || c.Layers.AnnotationData() != c.Layers.AnnotationData() {
+		t.Error("sub-services are created on every call, expected them created with the client")
+	}
+}
@@ -0,0 +1,163 @@
+package books
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RequestLog describes one attempt at sending a request. Credentials are redacted from URL and RequestHeader.
+type RequestLog struct {
+	Method  string
+	URL     string
+	Attempt int
+
+	// Status is the HTTP status code of the response, zero if no response was received.
+	Status  int
+	Latency time.Duration
+
+	// Err is the transport or API error of the attempt, if any.
+	Err error
+
+	RequestHeader http.Header
+
+	// RequestBody and ResponseBody are only set when the client logs bodies, see SetLogBodies.
+	RequestBody  []byte
+	ResponseBody []byte
+}
+
+// Logger receives a RequestLog for every attempt made by the client.
+type Logger interface {
+	LogRequest(ctx context.Context, l *RequestLog)
+}
+
+// LoggerFunc adapts a function to the Logger interface.
+type LoggerFunc func(ctx context.Context, l *RequestLog)
+
+// LogRequest calls f(ctx, l).
+func (f LoggerFunc) LogRequest(ctx context.Context, l *RequestLog) { f(ctx, l) }
+
+// Printer is implemented by *log.Logger.
+type Printer interface {
+	Printf(format string, v ...interface{})
+}
+
+// NewPrintfLogger returns a Logger writing one line per attempt to p, followed by the bodies when they are logged.
+//
+//	books.New(oauthClient, books.SetLogger(books.NewPrintfLogger(log.New(os.Stderr, "", log.LstdFlags))))
+func NewPrintfLogger(p Printer) Logger {
+	return LoggerFunc(func(ctx context.Context, l *RequestLog) {
+		line := fmt.Sprintf("books: %s %s attempt=%d status=%d latency=%v", l.Method, l.URL, l.Attempt, l.Status, l.Latency)
+		if l.Err != nil {
+			line += fmt.Sprintf(" error=%q", l.Err.Error())
+		}
+		if len(l.RequestBody) > 0 {
+			line += fmt.Sprintf("\nrequest body: %s", l.RequestBody)
+		}
+		if len(l.ResponseBody) > 0 {
+			line += fmt.Sprintf("\nresponse body: %s", l.ResponseBody)
+		}
+		p.Printf("%s", line)
+	})
+}
+
+// SetLogger is a client option for logging every attempt made by the client.
+func SetLogger(l Logger) ClientOpt {
+	return func(c *Client) error {
+		c.logger = l
+		return nil
+	}
+}
+
+// SetLogBodies is a client option for including request and response bodies in the logs. Bodies may contain
+// personal data, such as the user's notes.
+func SetLogBodies(logBodies bool) ClientOpt {
+	return func(c *Client) error {
+		c.logBodies = logBodies
+		return nil
+	}
+}
+
+// redactedHeaders are the request headers whose values are never logged.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "X-Goog-Api-Key"}
+
+// redactURL returns u as a string, without the API key or oauth token.
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	redacted := false
+	for _, k := range []string{"key", "access_token"} {
+		if q.Get(k) != "" {
+			q.Set(k, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.String()
+	}
+
+	r := *u
+	r.RawQuery = q.Encode()
+	return r.String()
+}
+
+// redactError redacts credentials from the URL held by a transport error, as url.Error includes it in its message.
+func redactError(err error) error {
+	urlErr, ok := err.(*url.Error)
+	if !ok {
+		return err
+	}
+
+	u, parseErr := url.Parse(urlErr.URL)
+	if parseErr != nil {
+		return err
+	}
+
+	redacted := *urlErr
+	redacted.URL = redactURL(u)
+	return &redacted
+}
+
+// newRequestLog returns the log of the attempt-th attempt at sending req. The response body of resp is read,
+// and replaced, when bodies are logged.
+func (c *Client) newRequestLog(req *http.Request, attempt int, start time.Time, resp *http.Response, err error) *RequestLog {
+	l := &RequestLog{
+		Method:        req.Method,
+		URL:           redactURL(req.URL),
+		Attempt:       attempt,
+		Latency:       time.Since(start),
+		Err:           err,
+		RequestHeader: req.Header.Clone(),
+	}
+	for _, h := range redactedHeaders {
+		if l.RequestHeader.Get(h) != "" {
+			l.RequestHeader.Set(h, "REDACTED")
+		}
+	}
+
+	if resp != nil {
+		l.Status = resp.StatusCode
+	}
+
+	if !c.logBodies {
+		return l
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			l.RequestBody, _ = ioutil.ReadAll(body)
+			body.Close()
+		}
+	}
+	if resp != nil {
+		data, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+		l.ResponseBody = data
+	}
+
+	return l
+}
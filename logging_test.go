@@ -0,0 +1,193 @@
+package books
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// memoryLogger is a Logger keeping the logs in memory.
+type memoryLogger struct {
+	logs []*RequestLog
+}
+
+func (m *memoryLogger) LogRequest(ctx context.Context, l *RequestLog) { m.logs = append(m.logs, l) }
+
+func TestLogger(t *testing.T) {
+	setup()
+	defer teardown()
+
+	logger := &memoryLogger{}
+	SetLogger(logger)(client)
+	SetToken("secret-token")(client)
+	SetAPIKey("secret-key")(client)
+	setupRetry(t, nil)
+
+	attempts := 0
+	mux.HandleFunc("/mylibrary/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error":{"code":503,"message":"Backend Error","errors":[{"reason":"backendError"}]}}`)
+			return
+		}
+		fmt.Fprint(w, `{"items":[]}`)
+	})
+
+	if _, _, err := client.Shelves.List(nil); err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+
+	if len(logger.logs) != 2 {
+		t.Fatalf("logged %d attempts, expected 2", len(logger.logs))
+	}
+
+	first, second := logger.logs[0], logger.logs[1]
+	if first.Method != "GET" || first.Attempt != 1 || first.Status != 503 || !isErrorReason(first.Err, "backendError") {
+		t.Errorf("first attempt log = %+v", first)
+	}
+	if second.Attempt != 2 || second.Status != 200 || second.Err != nil {
+		t.Errorf("second attempt log = %+v", second)
+	}
+
+	for _, l := range logger.logs {
+		if strings.Contains(l.URL, "secret-key") || !strings.Contains(l.URL, "key=REDACTED") {
+			t.Errorf("URL = %q, expected the API key redacted", l.URL)
+		}
+		if got := l.RequestHeader.Get("Authorization"); got != "REDACTED" {
+			t.Errorf("Authorization = %q, expected it redacted", got)
+		}
+		if l.RequestBody != nil || l.ResponseBody != nil {
+			t.Errorf("bodies were logged without SetLogBodies")
+		}
+	}
+}
+
+// isErrorReason reports whether err is an *ErrorResponse with reason.
+func isErrorReason(err error, reason string) bool {
+	errResp, ok := err.(*ErrorResponse)
+	return ok && errResp.Reason() == reason
+}
+
+func TestLogger_bodies(t *testing.T) {
+	setup()
+	defer teardown()
+
+	logger := &memoryLogger{}
+	SetLogger(logger)(client)
+	SetLogBodies(true)(client)
+
+	mux.HandleFunc("/mylibrary/annotations", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"AO7b3V1","data":"note"}`)
+	})
+
+	annotation, _, err := client.Annotations.Insert(&Annotation{Data: String("note")}, nil)
+	if err != nil {
+		t.Fatalf("Insert() returned an error: %v", err)
+	}
+	if annotation.ID == nil || *annotation.ID != "AO7b3V1" {
+		t.Errorf("Insert() returned %+v, expected the response to still be decoded", annotation)
+	}
+
+	l := logger.logs[0]
+	if got, expected := strings.TrimSpace(string(l.RequestBody)), `{"data":"note"}`; got != expected {
+		t.Errorf("RequestBody = %q, expected %q", got, expected)
+	}
+	if got, expected := string(l.ResponseBody), `{"id":"AO7b3V1","data":"note"}`; got != expected {
+		t.Errorf("ResponseBody = %q, expected %q", got, expected)
+	}
+}
+
+// printer is a Printer keeping the printed lines.
+type printer struct {
+	lines []string
+}
+
+func (p *printer) Printf(format string, v ...interface{}) {
+	p.lines = append(p.lines, fmt.Sprintf(format, v...))
+}
+
+func TestNewPrintfLogger(t *testing.T) {
+	setup()
+	defer teardown()
+
+	p := &printer{}
+	SetLogger(NewPrintfLogger(p))(client)
+	SetToken("secret-token")(client)
+
+	mux.HandleFunc("/mylibrary/bookshelves/4", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"code":404,"message":"Not Found"}}`)
+	})
+
+	client.Shelves.Get(ShelfHaveRead, nil)
+
+	if len(p.lines) != 1 {
+		t.Fatalf("printed %d lines, expected 1", len(p.lines))
+	}
+	line := p.lines[0]
+	for _, expected := range []string{"GET ", "/mylibrary/bookshelves/4", "attempt=1", "status=404", "latency=", "Not Found"} {
+		if !strings.Contains(line, expected) {
+			t.Errorf("line %q does not contain %q", line, expected)
+		}
+	}
+	if strings.Contains(line, "secret-token") {
+		t.Errorf("line %q contains the token", line)
+	}
+}
+
+func TestLogger_transportErrorRedacted(t *testing.T) {
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("dial tcp: connection refused")
+	})
+	c := NewClient(&http.Client{Transport: transport})
+	p := &printer{}
+	SetLogger(NewPrintfLogger(p))(c)
+	SetAPIKey("SECRETKEY")(c)
+
+	_, _, err := c.Volumes.Get("x", nil)
+	if err == nil {
+		t.Fatal("Get() returned no error")
+	}
+	if strings.Contains(err.Error(), "SECRETKEY") {
+		t.Errorf("error %q contains the API key", err)
+	}
+
+	if len(p.lines) != 1 {
+		t.Fatalf("printed %d lines, expected 1", len(p.lines))
+	}
+	if line := p.lines[0]; strings.Contains(line, "SECRETKEY") || !strings.Contains(line, "connection refused") {
+		t.Errorf("line %q contains the API key or misses the error", line)
+	}
+}
+
+func TestLogger_apiKeyHeaderRedacted(t *testing.T) {
+	setup()
+	defer teardown()
+
+	logger := &memoryLogger{}
+	SetLogger(logger)(client)
+
+	mux.HandleFunc("/volumes/x", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"x"}`)
+	})
+
+	req, err := client.NewRequest("GET", "volumes/x", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() returned an error: %v", err)
+	}
+	req.Header.Set("X-Goog-Api-Key", "secret-key")
+	if _, err := client.Do(req, nil); err != nil {
+		t.Fatalf("Do() returned an error: %v", err)
+	}
+
+	if len(logger.logs) != 1 {
+		t.Fatalf("logged %d attempts, expected 1", len(logger.logs))
+	}
+	if got := logger.logs[0].RequestHeader.Get("X-Goog-Api-Key"); got != "REDACTED" {
+		t.Errorf("X-Goog-Api-Key = %q, expected it redacted", got)
+	}
+}
@@ -0,0 +1,88 @@
+package books
+
+import (
+	"context"
+	"net/http"
+)
+
+// Operation describes the API method a request is made for. The services of the client attach it to the
+// context of their requests, where middleware can read it with OperationFromContext.
+type Operation struct {
+	// Service is the client service making the call, for example "Annotations".
+	Service string
+
+	// Name is the API method, for example "books.mylibrary.annotations.list".
+	Name string
+
+	// The identifiers the call is about, when it has any.
+	VolumeID     string
+	AnnotationID string
+	UserID       string
+	Shelf        *ShelfID
+
+	// PageToken and StartIndex locate the page requested by list calls.
+	PageToken  string
+	StartIndex int
+}
+
+type operationKey struct{}
+
+// withOperation returns a copy of ctx carrying op.
+func withOperation(ctx context.Context, op Operation) context.Context {
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+// OperationFromContext returns the operation attached to ctx by the service making the request, if any.
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationKey{}).(Operation)
+	return op, ok
+}
+
+// Call is a request on its way through the middleware chain of the client.
+type Call struct {
+	// Operation is the API method the request is made for. It is the zero Operation for requests that were not
+	// made by a service of the client.
+	Operation Operation
+
+	// Request is the HTTP request to send. Middleware may replace it, for example to add headers.
+	Request *http.Request
+
+	// Result is the value the response is decoded into, the v argument of Client.Do. Middleware answering a
+	// call without sending it must fill it in.
+	Result interface{}
+}
+
+// Handler sends a call and returns its decoded response. API errors are returned as an *ErrorResponse.
+type Handler func(call *Call) (*Response, error)
+
+// Middleware wraps a Handler, to act before a call is sent or after its response is decoded, or to answer the
+// call itself.
+//
+//	func header(next books.Handler) books.Handler {
+//		return func(call *books.Call) (*books.Response, error) {
+//			call.Request.Header.Set("X-Request-Source", call.Operation.Name)
+//			return next(call)
+//		}
+//	}
+type Middleware func(next Handler) Handler
+
+// SetMiddleware is a client option for adding middleware to the call path of Client.Do. The first middleware is
+// the outermost one, it sees the call first and the response last.
+func SetMiddleware(m ...Middleware) ClientOpt {
+	return func(c *Client) error {
+		c.middleware = append(c.middleware, m...)
+		return nil
+	}
+}
+
+// handler returns the middleware chain of the client, ending with do.
+func (c *Client) handler() Handler {
+	h := func(call *Call) (*Response, error) {
+		return c.do(call.Request, call.Result)
+	}
+
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
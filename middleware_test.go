@@ -0,0 +1,128 @@
+package books
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMiddleware_operation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calls []Operation
+	var results []interface{}
+	SetMiddleware(func(next Handler) Handler {
+		return func(call *Call) (*Response, error) {
+			call.Request.Header.Set("X-Operation", call.Operation.Name)
+			resp, err := next(call)
+			calls = append(calls, call.Operation)
+			results = append(results, err)
+			return resp, err
+		}
+	})(client)
+
+	mux.HandleFunc("/volumes/v1", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Operation"); got != "books.volumes.get" {
+			t.Errorf("X-Operation = %q, expected books.volumes.get", got)
+		}
+		fmt.Fprint(w, `{"id":"v1"}`)
+	})
+	mux.HandleFunc("/mylibrary/bookshelves/2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"code":404,"message":"Not Found","errors":[{"reason":"notFound"}]}}`)
+	})
+
+	if _, _, err := client.Volumes.Get("v1", nil); err != nil {
+		t.Fatalf("Volumes.Get() returned an error: %v", err)
+	}
+	if _, _, err := client.Shelves.Get(ShelfToRead, nil); !IsNotFound(err) {
+		t.Fatalf("Shelves.Get() returned %v, expected a not found error", err)
+	}
+
+	shelf := ShelfToRead
+	want := []Operation{
+		{Service: "Volumes", Name: "books.volumes.get", VolumeID: "v1"},
+		{Service: "Shelves", Name: "books.mylibrary.bookshelves.get", Shelf: &shelf},
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("operations = %+v, expected %+v", calls, want)
+	}
+	if results[0] != nil {
+		t.Errorf("first call error = %v, expected nil", results[0])
+	}
+	if _, ok := results[1].(*ErrorResponse); !ok {
+		t.Errorf("second call error = %T, expected *ErrorResponse", results[1])
+	}
+}
+
+func TestMiddleware_order(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var trace []string
+	tracer := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(call *Call) (*Response, error) {
+				trace = append(trace, name+" in")
+				resp, err := next(call)
+				trace = append(trace, name+" out")
+				return resp, err
+			}
+		}
+	}
+	SetMiddleware(tracer("a"), tracer("b"))(client)
+	SetMiddleware(tracer("c"))(client)
+
+	mux.HandleFunc("/mylibrary/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items":[]}`)
+	})
+
+	if _, _, err := client.Shelves.List(nil); err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+
+	want := []string{"a in", "b in", "c in", "c out", "b out", "a out"}
+	if !reflect.DeepEqual(trace, want) {
+		t.Errorf("trace = %v, expected %v", trace, want)
+	}
+}
+
+func TestMiddleware_shortCircuit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	SetMiddleware(func(next Handler) Handler {
+		return func(call *Call) (*Response, error) {
+			root, ok := call.Result.(*shelvesRoot)
+			if !ok {
+				return next(call)
+			}
+			root.Shelves = []Shelf{{Title: String("Cached")}}
+			return &Response{}, nil
+		}
+	})(client)
+
+	mux.HandleFunc("/mylibrary/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request was sent to the server")
+	})
+
+	shelves, _, err := client.Shelves.List(nil)
+	if err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+	if len(shelves) != 1 || *shelves[0].Title != "Cached" {
+		t.Errorf("List() returned %+v, expected the middleware result", shelves)
+	}
+}
+
+func TestOperationFromContext_none(t *testing.T) {
+	setup()
+	defer teardown()
+
+	req, _ := client.NewRequest("GET", "volumes", nil)
+	if _, ok := OperationFromContext(req.Context()); ok {
+		t.Error("OperationFromContext() found an operation on a plain request")
+	}
+}
@@ -0,0 +1,219 @@
+package books
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter decides when requests may be sent. Client.Do calls Wait before sending each request, including
+// retries, and Throttled when the server still answered with a rate limit error.
+//
+// Requests are grouped by key, so that each user sharing a Client gets their own quota. The key is the one set
+// on the request context with WithRateLimitKey, or else derived from the Authorization header of the request.
+type RateLimiter interface {
+	// Wait blocks until a request for key may be sent, or until ctx is done.
+	Wait(ctx context.Context, key string) error
+
+	// Throttled reports that a request for key was rejected by the server for exceeding a rate limit.
+	Throttled(key string)
+}
+
+// SetRateLimiter is a client option for limiting the rate of requests sent by the client.
+func SetRateLimiter(l RateLimiter) ClientOpt {
+	return func(c *Client) error {
+		c.rateLimiter = l
+		return nil
+	}
+}
+
+type rateLimitKey struct{}
+
+// WithRateLimitKey returns a copy of ctx that makes requests made with it count against the rate limit of key,
+// typically a user ID. It is needed when the oauth token is added by the http.Client transport, out of sight
+// of the rate limiter.
+func WithRateLimitKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, rateLimitKey{}, key)
+}
+
+// requestRateLimitKey returns the rate limit key of req.
+func requestRateLimitKey(req *http.Request) string {
+	if key, ok := req.Context().Value(rateLimitKey{}).(string); ok {
+		return key
+	}
+
+	auth := req.Header.Get("Authorization")
+	if auth == "" {
+		return ""
+	}
+
+	// Avoid keeping tokens in memory longer than the request.
+	sum := sha256.Sum256([]byte(auth))
+	return hex.EncodeToString(sum[:8])
+}
+
+// TokenBucketLimiter is a RateLimiter with one token bucket per key. Each bucket holds up to Burst tokens and
+// refills at QPS tokens per second; sending a request takes one token.
+//
+// When a key is throttled by the server, the refill rate of its bucket is halved, down to MinQPS, and then
+// recovers by a twentieth of QPS for every request sent. The buckets of idle keys are dropped, so rotating keys
+// such as oauth tokens do not accumulate.
+type TokenBucketLimiter struct {
+	// QPS is the sustained number of requests per second allowed for each key.
+	QPS float64
+
+	// Burst is the number of requests that can be sent at once for each key.
+	Burst int
+
+	// MinQPS is the lowest rate a throttled key is slowed down to.
+	MinQPS float64
+
+	// Clock is used to refill buckets and wait for tokens. It defaults to the system clock.
+	Clock Clock
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+const (
+	// bucketSweepInterval is how often idle buckets are looked for.
+	bucketSweepInterval = time.Minute
+
+	// bucketIdleTimeout is how long a throttled key is remembered without requests.
+	bucketIdleTimeout = 10 * time.Minute
+)
+
+// bucket holds the state of one key.
+type bucket struct {
+	tokens float64
+	rate   float64
+	last   time.Time
+}
+
+// NewTokenBucketLimiter returns a TokenBucketLimiter allowing qps requests per second with bursts of burst
+// requests, for every key. The Books API quota is expressed per 100 seconds, a quota of 1000 requests per user
+// per 100 seconds is a qps of 10.
+func NewTokenBucketLimiter(qps float64, burst int) *TokenBucketLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &TokenBucketLimiter{
+		QPS:    qps,
+		Burst:  burst,
+		MinQPS: qps / 16,
+	}
+}
+
+func (l *TokenBucketLimiter) clock() Clock {
+	if l.Clock == nil {
+		return realClock{}
+	}
+	return l.Clock
+}
+
+// bucket returns the bucket of key, refilled up to now. l.mu must be held.
+func (l *TokenBucketLimiter) bucket(key string, now time.Time) *bucket {
+	if l.buckets == nil {
+		l.buckets = make(map[string]*bucket)
+	}
+	if now.Sub(l.lastSweep) >= bucketSweepInterval {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.Burst), rate: l.QPS, last: now}
+		l.buckets[key] = b
+		return b
+	}
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if max := float64(l.Burst); b.tokens > max {
+			b.tokens = max
+		}
+		b.last = now
+	}
+	return b
+}
+
+// sweep removes the buckets of idle keys, as keys such as token hashes come and go. A bucket that has refilled and
+// recovered its rate is the same as a new one, so removing it changes nothing. A throttled bucket is removed
+// once idle for bucketIdleTimeout. l.mu must be held.
+func (l *TokenBucketLimiter) sweep(now time.Time) {
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		idle := now.Sub(b.last)
+		if b.tokens+idle.Seconds()*b.rate < float64(l.Burst) {
+			continue
+		}
+		if b.rate >= l.QPS || idle >= bucketIdleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Wait takes a token from the bucket of key, blocking until one is available.
+func (l *TokenBucketLimiter) Wait(ctx context.Context, key string) error {
+	if l.QPS <= 0 {
+		return nil
+	}
+
+	clock := l.clock()
+
+	l.mu.Lock()
+	b := l.bucket(key, clock.Now())
+	// Reserve the token now, so concurrent callers queue up behind each other.
+	b.tokens--
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	if b.rate < l.QPS {
+		b.rate += l.QPS / 20
+		if b.rate > l.QPS {
+			b.rate = l.QPS
+		}
+	}
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	select {
+	case <-clock.After(delay):
+		return nil
+	case <-ctx.Done():
+		// Hand the reserved token back.
+		l.mu.Lock()
+		b.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Throttled halves the rate of key and empties its bucket.
+func (l *TokenBucketLimiter) Throttled(key string) {
+	if l.QPS <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(key, l.clock().Now())
+	b.rate /= 2
+	if b.rate < l.MinQPS {
+		b.rate = l.MinQPS
+	}
+	if b.tokens > 0 {
+		b.tokens = 0
+	}
+}
@@ -0,0 +1,191 @@
+package books
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLimiter(qps float64, burst int) (*TokenBucketLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewTokenBucketLimiter(qps, burst)
+	l.Clock = clock
+	return l, clock
+}
+
+func TestTokenBucketLimiter_burst(t *testing.T) {
+	l, clock := newTestLimiter(2, 3)
+
+	for i := 0; i < 5; i++ {
+		if err := l.Wait(context.Background(), "user"); err != nil {
+			t.Fatalf("Wait() returned an error: %v", err)
+		}
+	}
+
+	// The first 3 requests use the burst, the next ones wait for a token at 2 per second.
+	expected := []time.Duration{500 * time.Millisecond, 500 * time.Millisecond}
+	if !reflect.DeepEqual(clock.waits, expected) {
+		t.Errorf("waits = %v, expected %v", clock.waits, expected)
+	}
+}
+
+func TestTokenBucketLimiter_keys(t *testing.T) {
+	l, clock := newTestLimiter(1, 1)
+
+	for _, key := range []string{"alice", "bob", "carol"} {
+		if err := l.Wait(context.Background(), key); err != nil {
+			t.Fatalf("Wait(%q) returned an error: %v", key, err)
+		}
+	}
+
+	if len(clock.waits) != 0 {
+		t.Errorf("waits = %v, expected keys not to wait for each other", clock.waits)
+	}
+}
+
+func TestTokenBucketLimiter_throttled(t *testing.T) {
+	l, clock := newTestLimiter(4, 1)
+	ctx := context.Background()
+
+	l.Wait(ctx, "user")
+	l.Throttled("user")
+	l.Wait(ctx, "user")
+
+	// The rate was halved to 2 per second.
+	if expected := []time.Duration{500 * time.Millisecond}; !reflect.DeepEqual(clock.waits, expected) {
+		t.Errorf("waits after throttling = %v, expected %v", clock.waits, expected)
+	}
+
+	for i := 0; i < 10; i++ {
+		l.Throttled("user")
+	}
+	if got, expected := l.buckets["user"].rate, l.MinQPS; got != expected {
+		t.Errorf("rate after repeated throttling = %v, expected MinQPS %v", got, expected)
+	}
+
+	for i := 0; i < 40; i++ {
+		l.Wait(ctx, "user")
+	}
+	if got := l.buckets["user"].rate; got != l.QPS {
+		t.Errorf("rate after recovery = %v, expected QPS %v", got, l.QPS)
+	}
+}
+
+func TestTokenBucketLimiter_evictsIdleBuckets(t *testing.T) {
+	l, clock := newTestLimiter(1, 1)
+
+	for i := 0; i < 100; i++ {
+		l.Wait(context.Background(), fmt.Sprintf("token-%d", i))
+	}
+	l.Throttled("throttled")
+	if got := len(l.buckets); got != 101 {
+		t.Fatalf("%d buckets, expected 101", got)
+	}
+
+	// Refilled buckets are dropped, the throttled one is kept until it has been idle long enough.
+	clock.now = clock.now.Add(2 * time.Minute)
+	l.Wait(context.Background(), "new")
+	if _, ok := l.buckets["throttled"]; !ok || len(l.buckets) != 2 {
+		t.Errorf("buckets after 2 minutes = %d, expected the throttled and the new one", len(l.buckets))
+	}
+
+	clock.now = clock.now.Add(bucketIdleTimeout)
+	l.Wait(context.Background(), "new")
+	if _, ok := l.buckets["throttled"]; ok || len(l.buckets) != 1 {
+		t.Errorf("buckets after the idle timeout = %d, expected the new one", len(l.buckets))
+	}
+}
+
+// blockingClock is a Clock whose timers never fire.
+type blockingClock struct{}
+
+func (blockingClock) Now() time.Time                       { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }
+func (blockingClock) After(time.Duration) <-chan time.Time { return nil }
+
+func TestTokenBucketLimiter_canceled(t *testing.T) {
+	l := NewTokenBucketLimiter(1, 1)
+	l.Clock = blockingClock{}
+
+	l.Wait(context.Background(), "user")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx, "user"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() error = %v, expected %v", err, context.Canceled)
+	}
+
+	if got := l.buckets["user"].tokens; got != 0 {
+		t.Errorf("tokens = %v, expected the canceled reservation to be returned", got)
+	}
+}
+
+// recordingLimiter is a RateLimiter that records its calls.
+type recordingLimiter struct {
+	waits     []string
+	throttled []string
+}
+
+func (l *recordingLimiter) Wait(ctx context.Context, key string) error {
+	l.waits = append(l.waits, key)
+	return nil
+}
+
+func (l *recordingLimiter) Throttled(key string) { l.throttled = append(l.throttled, key) }
+
+func TestClient_rateLimiter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	limiter := &recordingLimiter{}
+	SetRateLimiter(limiter)(client)
+	setupRetry(t, nil)
+
+	attempts := 0
+	mux.HandleFunc("/mylibrary/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":{"code":403,"message":"User Rate Limit Exceeded","errors":[{"reason":"userRateLimitExceeded"}]}}`)
+			return
+		}
+		fmt.Fprint(w, `{"items":[]}`)
+	})
+
+	ctx := WithRateLimitKey(context.Background(), "user-1")
+	if _, _, err := client.Shelves.ListContext(ctx, nil); err != nil {
+		t.Fatalf("ListContext() returned an error: %v", err)
+	}
+
+	if expected := []string{"user-1", "user-1"}; !reflect.DeepEqual(limiter.waits, expected) {
+		t.Errorf("waits = %v, expected %v", limiter.waits, expected)
+	}
+	if expected := []string{"user-1"}; !reflect.DeepEqual(limiter.throttled, expected) {
+		t.Errorf("throttled = %v, expected %v", limiter.throttled, expected)
+	}
+}
+
+func TestRequestRateLimitKey(t *testing.T) {
+	c, _ := New(nil, SetToken("secret-token"))
+
+	req, _ := c.NewRequest("GET", "/", nil)
+	key := requestRateLimitKey(req)
+	if key == "" || strings.Contains(key, "secret-token") {
+		t.Errorf("requestRateLimitKey() = %q, expected a digest of the token", key)
+	}
+
+	other, _ := New(nil, SetToken("other-token"))
+	req2, _ := other.NewRequest("GET", "/", nil)
+	if requestRateLimitKey(req2) == key {
+		t.Error("requestRateLimitKey() returned the same key for different tokens")
+	}
+
+	req3, _ := c.NewRequestWithContext(WithRateLimitKey(context.Background(), "user-1"), "GET", "/", nil)
+	if got := requestRateLimitKey(req3); got != "user-1" {
+		t.Errorf("requestRateLimitKey() = %q, expected the context key", got)
+	}
+}
@@ -0,0 +1,137 @@
+package books
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ReadingPositionsService defines the behavior required by types that want to implement a new ReadingPosition type.
+type ReadingPositionsService interface {
+	Get(string, string) (*ReadingPosition, *Response, error)
+	GetContext(context.Context, string, string) (*ReadingPosition, *Response, error)
+	SetPosition(string, string, time.Time, ReadingAction, string) (*Response, error)
+	SetPositionContext(context.Context, string, string, time.Time, ReadingAction, string) (*Response, error)
+}
+
+// GoogleReadingPositionsService implements the ReadingPositionsService interface.
+type GoogleReadingPositionsService struct {
+	client *Client
+}
+
+// ReadingPosition represents a Google Book ReadingPosition resource, the place the user stopped reading a volume
+// at. Each position is in the format of one of the readers of the volume.
+type ReadingPosition struct {
+	Kind            *string    `json:"kind,omitempty"`
+	VolumeID        *string    `json:"volumeId,omitempty"`
+	EpubCfiPosition *string    `json:"epubCfiPosition,omitempty"`
+	GbImagePosition *string    `json:"gbImagePosition,omitempty"`
+	GbTextPosition  *string    `json:"gbTextPosition,omitempty"`
+	PdfPosition     *string    `json:"pdfPosition,omitempty"`
+	Updated         *time.Time `json:"updated,omitempty"`
+}
+
+// ReadingAction is the user action that moved a reading position.
+type ReadingAction string
+
+// Values accepted by the action parameter.
+const (
+	ReadingActionBookmark ReadingAction = "bookmark"
+	ReadingActionChapter  ReadingAction = "chapter"
+	ReadingActionNextPage ReadingAction = "next-page"
+	ReadingActionPrevPage ReadingAction = "prev-page"
+	ReadingActionScroll   ReadingAction = "scroll"
+	ReadingActionSearch   ReadingAction = "search"
+)
+
+// readingPositionGetParams specifies the parameters sent to the books.mylibrary.readingpositions.get API.
+type readingPositionGetParams struct {
+	ContentVersion string `url:"contentVersion,omitempty"`
+}
+
+// readingPositionSetParams specifies the parameters sent to the books.mylibrary.readingpositions.setPosition API.
+type readingPositionSetParams struct {
+	Position     string        `url:"position"`
+	Timestamp    string        `url:"timestamp"`
+	Action       ReadingAction `url:"action,omitempty"`
+	DeviceCookie string        `url:"deviceCookie,omitempty"`
+}
+
+// Get will call the books.mylibrary.readingpositions.get API.
+// contentVersion is optional, it selects the version of the volume content the position is for.
+// https://www.googleapis.com/books/v1/mylibrary/readingpositions/{volumeId}
+func (v *GoogleReadingPositionsService) Get(volumeID string, contentVersion string) (*ReadingPosition, *Response, error) {
+	return v.GetContext(context.Background(), volumeID, contentVersion)
+}
+
+// GetContext is like Get but takes a context.
+func (v *GoogleReadingPositionsService) GetContext(ctx context.Context, volumeID string, contentVersion string) (*ReadingPosition, *Response, error) {
+	if volumeID == "" {
+		return nil, nil, errors.New("volumeID is a required field")
+	}
+
+	ctx = withOperation(ctx, Operation{Service: "ReadingPositions", Name: "books.mylibrary.readingpositions.get", VolumeID: volumeID})
+
+	url := fmt.Sprintf("mylibrary/readingpositions/%s", volumeID)
+	url, err := addOptions(url, &readingPositionGetParams{ContentVersion: contentVersion})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := v.client.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	position := new(ReadingPosition)
+	resp, err := v.client.Do(req, position)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return position, resp, err
+}
+
+// SetPosition will call the books.mylibrary.readingpositions.setPosition API to store the place the user
+// stopped reading at. timestamp is the time the position was reached, the API keeps the most recent position.
+// action and deviceCookie are optional.
+// https://www.googleapis.com/books/v1/mylibrary/readingpositions/{volumeId}/setPosition
+func (v *GoogleReadingPositionsService) SetPosition(volumeID string, position string, timestamp time.Time, action ReadingAction, deviceCookie string) (*Response, error) {
+	return v.SetPositionContext(context.Background(), volumeID, position, timestamp, action, deviceCookie)
+}
+
+// SetPositionContext is like SetPosition but takes a context.
+func (v *GoogleReadingPositionsService) SetPositionContext(ctx context.Context, volumeID string, position string, timestamp time.Time, action ReadingAction, deviceCookie string) (*Response, error) {
+	if volumeID == "" {
+		return nil, errors.New("volumeID is a required field")
+	}
+	if position == "" {
+		return nil, errors.New("position is a required field")
+	}
+	if timestamp.IsZero() {
+		return nil, errors.New("timestamp is a required field")
+	}
+
+	ctx = withOperation(ctx, Operation{Service: "ReadingPositions", Name: "books.mylibrary.readingpositions.setPosition", VolumeID: volumeID})
+
+	params := &readingPositionSetParams{
+		Position:     position,
+		Timestamp:    timestamp.UTC().Format(time.RFC3339Nano),
+		Action:       action,
+		DeviceCookie: deviceCookie,
+	}
+
+	url := fmt.Sprintf("mylibrary/readingpositions/%s/setPosition", volumeID)
+	url, err := addOptions(url, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := v.client.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.client.Do(req, nil)
+}
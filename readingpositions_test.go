@@ -0,0 +1,89 @@
+package books
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReadingPositionsGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/mylibrary/readingpositions/VN2jCgAAAEAJ", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"contentVersion": "0.1.0.0.preview.3"})
+		fmt.Fprint(w, `{
+			"kind": "books#readingPosition",
+			"volumeId": "VN2jCgAAAEAJ",
+			"epubCfiPosition": "epubcfi(/6/4!/4/2/1:0)",
+			"gbTextPosition": "GBS.PA12.w.1.0.0",
+			"updated": "2016-03-13T21:07:49.000Z"
+		}`)
+	})
+
+	position, _, err := client.ReadingPositions.Get("VN2jCgAAAEAJ", "0.1.0.0.preview.3")
+	if err != nil {
+		t.Fatalf("Get() returned an error: %v", err)
+	}
+
+	updated := time.Date(2016, 3, 13, 21, 7, 49, 0, time.UTC)
+	expected := &ReadingPosition{
+		Kind:            String("books#readingPosition"),
+		VolumeID:        String("VN2jCgAAAEAJ"),
+		EpubCfiPosition: String("epubcfi(/6/4!/4/2/1:0)"),
+		GbTextPosition:  String("GBS.PA12.w.1.0.0"),
+		Updated:         &updated,
+	}
+	if !reflect.DeepEqual(position, expected) {
+		t.Errorf("Get() returned %+v, expected %+v", position, expected)
+	}
+}
+
+func TestReadingPositionsSetPosition(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/mylibrary/readingpositions/VN2jCgAAAEAJ/setPosition", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{
+			"position":     "GBS.PA12.w.1.0.0",
+			"timestamp":    "2016-03-13T21:07:49.5Z",
+			"action":       "next-page",
+			"deviceCookie": "device-1",
+		})
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	timestamp := time.Date(2016, 3, 13, 22, 7, 49, 500000000, time.FixedZone("CET", 3600))
+	if _, err := client.ReadingPositions.SetPosition("VN2jCgAAAEAJ", "GBS.PA12.w.1.0.0", timestamp, ReadingActionNextPage, "device-1"); err != nil {
+		t.Errorf("SetPosition() returned an error: %v", err)
+	}
+}
+
+func TestReadingPositions_requiredFields(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"Get without volume", func() error { _, _, err := client.ReadingPositions.Get("", ""); return err }},
+		{"SetPosition without volume", func() error { _, err := client.ReadingPositions.SetPosition("", "p", now, "", ""); return err }},
+		{"SetPosition without position", func() error { _, err := client.ReadingPositions.SetPosition("v", "", now, "", ""); return err }},
+		{"SetPosition without timestamp", func() error {
+			_, err := client.ReadingPositions.SetPosition("v", "p", time.Time{}, "", "")
+			return err
+		}},
+	}
+
+	setup()
+	defer teardown()
+
+	for _, tt := range tests {
+		if err := tt.call(); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package books
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Clock tells the time and waits for durations to pass. It is used by RetryPolicy, so tests can control time.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the Clock backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// RetryPolicy configures how Client.Do retries requests that fail with a transient error: a connection error,
+// one of RetryableStatuses, or an API error whose ErrorItem.Reason is one of RetryableReasons.
+//
+// Requests are retried with exponential backoff: the n-th retry waits BaseDelay * 2^(n-1), capped at MaxDelay,
+// and reduced by a random fraction of up to Jitter. A Retry-After header sent by the server takes precedence;
+// if it asks to wait longer than MaxDelay the request is not retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two attempts.
+	MaxDelay time.Duration
+
+	// Jitter is the fraction, between 0 and 1, of each delay that is randomized.
+	Jitter float64
+
+	// RetryableStatuses are the HTTP status codes that are retried.
+	RetryableStatuses []int
+
+	// RetryableReasons are the ErrorItem.Reason values that are retried, whatever the status code.
+	RetryableReasons []string
+
+	// RetryNonIdempotent allows retrying POST and PATCH requests. By default only idempotent methods are
+	// retried, as a retried POST may apply the same change twice.
+	RetryNonIdempotent bool
+
+	// Clock is used to wait between attempts. It defaults to the system clock.
+	Clock Clock
+
+	// random returns a number in [0, 1), it defaults to rand.Float64.
+	random func() float64
+}
+
+// DefaultRetryPolicy returns a RetryPolicy that retries the transient failures of the Google APIs up to 4 times.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.2,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableReasons: []string{
+			"rateLimitExceeded",
+			"userRateLimitExceeded",
+			"backendError",
+			"internalError",
+		},
+	}
+}
+
+// SetRetryPolicy is a client option for retrying requests that fail with a transient error.
+func SetRetryPolicy(p *RetryPolicy) ClientOpt {
+	return func(c *Client) error {
+		if p != nil && p.MaxAttempts < 1 {
+			return errors.New("retry policy MaxAttempts must be at least 1")
+		}
+
+		c.retryPolicy = p
+		return nil
+	}
+}
+
+func (p *RetryPolicy) clock() Clock {
+	if p.Clock == nil {
+		return realClock{}
+	}
+	return p.Clock
+}
+
+// retryDelay reports whether the request should be retried after attempt failed with resp and err, and how
+// long to wait before doing so.
+func (p *RetryPolicy) retryDelay(req *http.Request, attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxAttempts || err == nil {
+		return 0, false
+	}
+	if req.Context().Err() != nil || !p.canRetry(req) {
+		return 0, false
+	}
+	if !p.isTransient(resp, err) {
+		return 0, false
+	}
+
+	delay := p.backoff(attempt)
+	if resp != nil {
+		if after, ok := p.retryAfter(resp); ok {
+			if p.MaxDelay > 0 && after > p.MaxDelay {
+				return 0, false
+			}
+			delay = after
+		}
+	}
+
+	return delay, true
+}
+
+// canRetry reports whether req can be sent again.
+func (p *RetryPolicy) canRetry(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return p.RetryNonIdempotent
+}
+
+// isTransient reports whether an attempt that failed with resp and err, returned by the transport or
+// CheckResponse, is worth retrying.
+func (p *RetryPolicy) isTransient(resp *http.Response, err error) bool {
+	// The status is checked on the response, as error bodies are not always API errors.
+	if resp != nil {
+		for _, s := range p.RetryableStatuses {
+			if resp.StatusCode == s {
+				return true
+			}
+		}
+	}
+
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		for _, item := range errResp.CustomError.Errors {
+			for _, r := range p.RetryableReasons {
+				if item.Reason == r {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// backoff returns the jittered exponential delay before the retry following attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 {
+		random := p.random
+		if random == nil {
+			random = rand.Float64
+		}
+		delay -= time.Duration(p.Jitter * random() * float64(delay))
+	}
+
+	return delay
+}
+
+// retryAfter parses the Retry-After header of resp, given either in seconds or as an HTTP date.
+func (p *RetryPolicy) retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		d := t.Sub(p.clock().Now())
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+// wait blocks for d, or until ctx is done.
+func (p *RetryPolicy) wait(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-p.clock().After(d):
+		return nil
+	}
+}
+
+// rewind returns a copy of req with a fresh body, so it can be sent again.
+func rewind(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
+
+// send sends req, retrying it as configured by the retry policy of the client and pacing it with its rate
+// limiter. API errors are returned as an *ErrorResponse along with the response, whose body has then been read.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	var key string
+	if c.rateLimiter != nil {
+		key = requestRateLimitKey(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		r := req
+		if attempt > 1 {
+			var err error
+			if r, err = rewind(req); err != nil {
+				return nil, err
+			}
+		}
+
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(req.Context(), key); err != nil {
+				return nil, err
+			}
+		}
+
+		start := time.Now()
+		resp, err := c.client.Do(r)
+		err = redactError(err)
+
+		var entry *RequestLog
+		if c.logger != nil {
+			entry = c.newRequestLog(r, attempt, start, resp, err)
+		}
+
+		if err == nil && !(resp.StatusCode == http.StatusNotModified && isRevalidation(req)) {
+			err = CheckResponse(resp)
+		}
+
+		if entry != nil {
+			entry.Err = err
+			c.logger.LogRequest(req.Context(), entry)
+		}
+
+		if c.rateLimiter != nil && IsRateLimited(err) {
+			c.rateLimiter.Throttled(key)
+		}
+
+		delay, retry := c.retryPolicy.retryDelay(req, attempt, resp, err)
+		if !retry {
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := c.retryPolicy.wait(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package books
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock that does not wait, it records the requested delays and advances its time by them.
+type fakeClock struct {
+	now   time.Time
+	waits []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+// setupRetry installs a retry policy without jitter on the test client and returns its clock.
+func setupRetry(t *testing.T, configure func(*RetryPolicy)) *fakeClock {
+	clock := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	p := DefaultRetryPolicy()
+	p.BaseDelay = 100 * time.Millisecond
+	p.MaxDelay = 2 * time.Second
+	p.Jitter = 0
+	p.Clock = clock
+	if configure != nil {
+		configure(p)
+	}
+
+	if err := SetRetryPolicy(p)(client); err != nil {
+		t.Fatalf("SetRetryPolicy(): %v", err)
+	}
+	return clock
+}
+
+func TestRetry_transientStatus(t *testing.T) {
+	setup()
+	defer teardown()
+	clock := setupRetry(t, nil)
+
+	attempts := 0
+	mux.HandleFunc("/mylibrary/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error":{"code":503,"message":"Backend Error","errors":[{"reason":"backendError"}]}}`)
+			return
+		}
+		fmt.Fprint(w, `{"items":[{"id":1}]}`)
+	})
+
+	list, _, err := client.Shelves.List(nil)
+	if err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+	if len(list) != 1 {
+		t.Errorf("List() returned %d shelves, expected 1", len(list))
+	}
+
+	if expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}; !reflect.DeepEqual(clock.waits, expected) {
+		t.Errorf("waits = %v, expected %v", clock.waits, expected)
+	}
+}
+
+func TestRetry_transientStatusNotJSON(t *testing.T) {
+	setup()
+	defer teardown()
+	clock := setupRetry(t, nil)
+
+	attempts := 0
+	mux.HandleFunc("/mylibrary/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, "<html><body><h1>503 Service Unavailable</h1></body></html>")
+			return
+		}
+		fmt.Fprint(w, `{"items":[{"id":1}]}`)
+	})
+
+	if _, _, err := client.Shelves.List(nil); err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("server got %d attempts, expected 2", attempts)
+	}
+	if expected := []time.Duration{100 * time.Millisecond}; !reflect.DeepEqual(clock.waits, expected) {
+		t.Errorf("waits = %v, expected %v", clock.waits, expected)
+	}
+}
+
+func TestRetry_reason(t *testing.T) {
+	setup()
+	defer teardown()
+	setupRetry(t, nil)
+
+	attempts := 0
+	mux.HandleFunc("/mylibrary/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":{"code":403,"message":"Rate Limit Exceeded","errors":[{"reason":"userRateLimitExceeded"}]}}`)
+			return
+		}
+		fmt.Fprint(w, `{"items":[]}`)
+	})
+
+	if _, _, err := client.Shelves.List(nil); err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("sent %d attempts, expected 2", attempts)
+	}
+}
+
+func TestRetry_permanentError(t *testing.T) {
+	setup()
+	defer teardown()
+	clock := setupRetry(t, nil)
+
+	attempts := 0
+	mux.HandleFunc("/mylibrary/bookshelves/1099", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":{"code":403,"message":"Forbidden","errors":[{"reason":"forbidden"}]}}`)
+	})
+
+	_, resp, err := client.Shelves.Get(ShelfID(1099), nil)
+	if _, ok := err.(*ErrorResponse); !ok {
+		t.Errorf("Get() error = %#v, expected *ErrorResponse", err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Get() status = %v, expected %v", resp.StatusCode, http.StatusForbidden)
+	}
+	if attempts != 1 || len(clock.waits) != 0 {
+		t.Errorf("sent %d attempts with waits %v, expected a single attempt", attempts, clock.waits)
+	}
+}
+
+func TestRetry_maxAttempts(t *testing.T) {
+	setup()
+	defer teardown()
+	clock := setupRetry(t, func(p *RetryPolicy) { p.MaxAttempts = 6 })
+
+	attempts := 0
+	mux.HandleFunc("/mylibrary/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"error":{"code":500,"message":"Internal Error"}}`)
+	})
+
+	_, _, err := client.Shelves.List(nil)
+	errResp, ok := err.(*ErrorResponse)
+	if !ok || errResp.CustomError.Code != 500 {
+		t.Errorf("List() error = %#v, expected the last *ErrorResponse", err)
+	}
+	if attempts != 6 {
+		t.Errorf("sent %d attempts, expected 6", attempts)
+	}
+
+	// Delays double from 100ms and are capped at MaxDelay.
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, 1600 * time.Millisecond}
+	if !reflect.DeepEqual(clock.waits, expected) {
+		t.Errorf("waits = %v, expected %v", clock.waits, expected)
+	}
+}
+
+func TestRetry_nonIdempotent(t *testing.T) {
+	cases := []struct {
+		name     string
+		allow    bool
+		attempts int
+	}{
+		{"not retried by default", false, 1},
+		{"retried when allowed", true, 2},
+	}
+
+	for _, c := range cases {
+		setup()
+		setupRetry(t, func(p *RetryPolicy) { p.RetryNonIdempotent = c.allow })
+
+		var bodies []string
+		mux.HandleFunc("/mylibrary/annotations", func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "POST")
+			b, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(b))
+			if len(bodies) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `{"id":"AO7b3V1"}`)
+		})
+
+		client.Annotations.Insert(&Annotation{Data: String("note")}, nil)
+
+		if len(bodies) != c.attempts {
+			t.Errorf("%q sent %d attempts, expected %d", c.name, len(bodies), c.attempts)
+		}
+		for _, b := range bodies {
+			if b != bodies[0] {
+				t.Errorf("%q retried with body %q, expected %q", c.name, b, bodies[0])
+			}
+		}
+		teardown()
+	}
+}
+
+func TestRetry_retryAfter(t *testing.T) {
+	cases := []struct {
+		name       string
+		retryAfter func(now time.Time) string
+		attempts   int
+		waits      []time.Duration
+	}{
+		{"seconds", func(time.Time) string { return "1" }, 2, []time.Duration{time.Second}},
+		// HTTP dates have a one second resolution.
+		{"http date", func(now time.Time) string { return now.Add(1500 * time.Millisecond).Format(http.TimeFormat) }, 2, []time.Duration{time.Second}},
+		{"longer than MaxDelay", func(time.Time) string { return "120" }, 1, nil},
+	}
+
+	for _, c := range cases {
+		setup()
+		clock := setupRetry(t, nil)
+
+		attempts := 0
+		mux.HandleFunc("/mylibrary/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts == 1 {
+				w.Header().Set("Retry-After", c.retryAfter(clock.now))
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			fmt.Fprint(w, `{"items":[]}`)
+		})
+
+		client.Shelves.List(nil)
+
+		if attempts != c.attempts {
+			t.Errorf("%q sent %d attempts, expected %d", c.name, attempts, c.attempts)
+		}
+		if !reflect.DeepEqual(clock.waits, c.waits) {
+			t.Errorf("%q waits = %v, expected %v", c.name, clock.waits, c.waits)
+		}
+		teardown()
+	}
+}
+
+func TestRetry_connectionError(t *testing.T) {
+	setup()
+	defer teardown()
+	setupRetry(t, nil)
+
+	attempts := 0
+	mux.HandleFunc("/mylibrary/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatalf("Hijack(): %v", err)
+			}
+			conn.Close()
+			return
+		}
+		fmt.Fprint(w, `{"items":[]}`)
+	})
+
+	if _, _, err := client.Shelves.List(nil); err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("sent %d attempts, expected 2", attempts)
+	}
+}
+
+func TestRetryPolicy_backoffJitter(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 10 * time.Second, Jitter: 0.5}
+
+	p.random = func() float64 { return 0 }
+	if got := p.backoff(3); got != 4*time.Second {
+		t.Errorf("backoff(3) without jitter = %v, expected %v", got, 4*time.Second)
+	}
+
+	p.random = func() float64 { return 0.5 }
+	if got := p.backoff(3); got != 3*time.Second {
+		t.Errorf("backoff(3) with jitter = %v, expected %v", got, 3*time.Second)
+	}
+
+	p.random = func() float64 { return 0 }
+	if got := p.backoff(60); got != 10*time.Second {
+		t.Errorf("backoff(60) = %v, expected it capped to %v", got, 10*time.Second)
+	}
+}
+
+func TestSetRetryPolicy_invalid(t *testing.T) {
+	if _, err := New(nil, SetRetryPolicy(&RetryPolicy{})); err == nil {
+		t.Error("New() expected an error for a policy without attempts")
+	}
+}
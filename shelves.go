@@ -1,8 +1,30 @@
 package books
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
 // ShelvesService defines the behavior required by types that want to implement a new Shelf type.
 type ShelvesService interface {
 	List(*ShelvesListOptions) ([]Shelf, *Response, error)
+	ListContext(context.Context, *ShelvesListOptions) ([]Shelf, *Response, error)
+	Get(ShelfID, *ShelvesListOptions) (*Shelf, *Response, error)
+	GetContext(context.Context, ShelfID, *ShelvesListOptions) (*Shelf, *Response, error)
+	ListForUser(string, *ShelvesListOptions) ([]Shelf, *Response, error)
+	ListForUserContext(context.Context, string, *ShelvesListOptions) ([]Shelf, *Response, error)
+	GetForUser(string, ShelfID, *ShelvesListOptions) (*Shelf, *Response, error)
+	GetForUserContext(context.Context, string, ShelfID, *ShelvesListOptions) (*Shelf, *Response, error)
+	AddVolume(ShelfID, string, *ShelfVolumeOptions) (*Response, error)
+	AddVolumeContext(context.Context, ShelfID, string, *ShelfVolumeOptions) (*Response, error)
+	RemoveVolume(ShelfID, string, *ShelfVolumeOptions) (*Response, error)
+	RemoveVolumeContext(context.Context, ShelfID, string, *ShelfVolumeOptions) (*Response, error)
+	MoveVolume(ShelfID, string, int, *ShelfVolumeOptions) (*Response, error)
+	MoveVolumeContext(context.Context, ShelfID, string, int, *ShelfVolumeOptions) (*Response, error)
+	ClearVolumes(ShelfID, *ShelfVolumeOptions) (*Response, error)
+	ClearVolumesContext(context.Context, ShelfID, *ShelfVolumeOptions) (*Response, error)
 }
 
 // GoogleShelvesService implements the VolumesService interface.
@@ -10,39 +32,155 @@ type GoogleShelvesService struct {
 	client *Client
 }
 
-// Shelf represents a Google Book Volume resource.
+// ShelfID identifies a bookshelf. The well-known shelves every user has are available as constants,
+// shelves created by the user have IDs of 1000 and above.
+type ShelfID int
+
+// Well-known shelf IDs.
+// https://developers.google.com/books/docs/v1/using#ids
+const (
+	ShelfFavorites       ShelfID = 0
+	ShelfPurchased       ShelfID = 1
+	ShelfToRead          ShelfID = 2
+	ShelfReadingNow      ShelfID = 3
+	ShelfHaveRead        ShelfID = 4
+	ShelfReviewed        ShelfID = 5
+	ShelfRecentlyViewed  ShelfID = 6
+	ShelfMyEBooks        ShelfID = 7
+	ShelfBooksForYou     ShelfID = 8
+	ShelfBrowsingHistory ShelfID = 9
+)
+
+// validate reports an error if id can not be a shelf ID.
+func (id ShelfID) validate() error {
+	if id < 0 {
+		return fmt.Errorf("invalid shelf ID %d", id)
+	}
+	return nil
+}
+
+// Shelf represents a Google Book Bookshelf resource.
 // https://developers.google.com/books/docs/v1/reference/bookshelves#resource
 type Shelf struct {
-	ID          *int    `json:"id,omitempty"`
-	Title       *string `json:"title,omitempty"`
-	VolumeCount *int    `json:"volumeCount,omitempty"`
-	Description *string `json:"description,omitempty"`
-	Updated     *string `json:"updated,omitempty"`
+	Kind               *string    `json:"kind,omitempty"`
+	ID                 *ShelfID   `json:"id,omitempty"`
+	SelfLink           *string    `json:"selfLink,omitempty"`
+	Title              *string    `json:"title,omitempty"`
+	Description        *string    `json:"description,omitempty"`
+	Access             *string    `json:"access,omitempty"`
+	VolumeCount        *int       `json:"volumeCount,omitempty"`
+	Created            *time.Time `json:"created,omitempty"`
+	Updated            *time.Time `json:"updated,omitempty"`
+	VolumesLastUpdated *time.Time `json:"volumesLastUpdated,omitempty"`
 }
 
 // shelvesRoot represents a response from Google Books API.
 // https://developers.google.com/books/docs/v1/reference/mylibrary/bookshelves/list#response
 type shelvesRoot struct {
+	Kind    *string `json:"kind,omitempty"`
 	Shelves []Shelf `json:"items"`
 }
 
+func (r *shelvesRoot) itemCount() int { return len(r.Shelves) }
+
 // ShelvesListOptions specifies the optional parameters needed to make API request.
-// books.mylibrary.bookshelves.list
+// books.mylibrary.bookshelves.list, books.mylibrary.bookshelves.get, books.bookshelves.list and books.bookshelves.get
 type ShelvesListOptions struct {
 	Source string `url:"source,omitempty"`
 	Fields string `url:"fields, omitempty,omitempty"`
 }
 
+// ShelfVolumeOptions specifies the optional parameters for the bookshelf volume mutation API calls.
+// Reason is only used by books.mylibrary.bookshelves.addVolume and removeVolume, for example "ONBOARDING".
+type ShelfVolumeOptions struct {
+	Reason string `url:"reason,omitempty"`
+	Source string `url:"source,omitempty"`
+}
+
+// shelfVolumeParams specifies the parameters sent to a bookshelf volume mutation API call.
+type shelfVolumeParams struct {
+	VolumeID       string `url:"volumeId,omitempty"`
+	VolumePosition *int   `url:"volumePosition,omitempty"`
+	ShelfVolumeOptions
+}
+
 // List will call the books.mylibrary.bookshelves.list API.
 // https://www.googleapis.com/books/v1/mylibrary/bookshelves
 func (v *GoogleShelvesService) List(opt *ShelvesListOptions) ([]Shelf, *Response, error) {
-	url := "mylibrary/bookshelves"
+	return v.ListContext(context.Background(), opt)
+}
+
+// ListContext is like List but takes a context.
+func (v *GoogleShelvesService) ListContext(ctx context.Context, opt *ShelvesListOptions) ([]Shelf, *Response, error) {
+	ctx = withOperation(ctx, Operation{Service: "Shelves", Name: "books.mylibrary.bookshelves.list"})
+
+	return v.list(ctx, "mylibrary/bookshelves", opt)
+}
+
+// Get will call the books.mylibrary.bookshelves.get API.
+// https://www.googleapis.com/books/v1/mylibrary/bookshelves/{shelf}
+func (v *GoogleShelvesService) Get(shelf ShelfID, opt *ShelvesListOptions) (*Shelf, *Response, error) {
+	return v.GetContext(context.Background(), shelf, opt)
+}
+
+// GetContext is like Get but takes a context.
+func (v *GoogleShelvesService) GetContext(ctx context.Context, shelf ShelfID, opt *ShelvesListOptions) (*Shelf, *Response, error) {
+	if err := shelf.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	ctx = withOperation(ctx, Operation{Service: "Shelves", Name: "books.mylibrary.bookshelves.get", Shelf: &shelf})
+
+	return v.get(ctx, fmt.Sprintf("mylibrary/bookshelves/%d", shelf), opt)
+}
+
+// ListForUser will call the books.bookshelves.list API to list the public shelves of another user.
+// It does not require an oauth token, an API key set with SetAPIKey is enough.
+// https://www.googleapis.com/books/v1/users/{userId}/bookshelves
+func (v *GoogleShelvesService) ListForUser(userID string, opt *ShelvesListOptions) ([]Shelf, *Response, error) {
+	return v.ListForUserContext(context.Background(), userID, opt)
+}
+
+// ListForUserContext is like ListForUser but takes a context.
+func (v *GoogleShelvesService) ListForUserContext(ctx context.Context, userID string, opt *ShelvesListOptions) ([]Shelf, *Response, error) {
+	if userID == "" {
+		return nil, nil, errors.New("userID is a required field")
+	}
+
+	ctx = withOperation(ctx, Operation{Service: "Shelves", Name: "books.bookshelves.list", UserID: userID})
+
+	return v.list(ctx, fmt.Sprintf("users/%s/bookshelves", userID), opt)
+}
+
+// GetForUser will call the books.bookshelves.get API to get a public shelf of another user.
+// It does not require an oauth token, an API key set with SetAPIKey is enough.
+// https://www.googleapis.com/books/v1/users/{userId}/bookshelves/{shelf}
+func (v *GoogleShelvesService) GetForUser(userID string, shelf ShelfID, opt *ShelvesListOptions) (*Shelf, *Response, error) {
+	return v.GetForUserContext(context.Background(), userID, shelf, opt)
+}
+
+// GetForUserContext is like GetForUser but takes a context.
+func (v *GoogleShelvesService) GetForUserContext(ctx context.Context, userID string, shelf ShelfID, opt *ShelvesListOptions) (*Shelf, *Response, error) {
+	if userID == "" {
+		return nil, nil, errors.New("userID is a required field")
+	}
+	if err := shelf.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	ctx = withOperation(ctx, Operation{Service: "Shelves", Name: "books.bookshelves.get", UserID: userID, Shelf: &shelf})
+
+	return v.get(ctx, fmt.Sprintf("users/%s/bookshelves/%d", userID, shelf), opt)
+}
+
+// list fetches the shelves listed at url.
+func (v *GoogleShelvesService) list(ctx context.Context, url string, opt *ShelvesListOptions) ([]Shelf, *Response, error) {
 	url, err := addOptions(url, opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := v.client.NewRequest("GET", url, nil)
+	req, err := v.client.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -55,3 +193,115 @@ func (v *GoogleShelvesService) List(opt *ShelvesListOptions) ([]Shelf, *Response
 
 	return root.Shelves, resp, err
 }
+
+// get fetches the single shelf at url.
+func (v *GoogleShelvesService) get(ctx context.Context, url string, opt *ShelvesListOptions) (*Shelf, *Response, error) {
+	url, err := addOptions(url, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := v.client.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shelf := new(Shelf)
+	resp, err := v.client.Do(req, shelf)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return shelf, resp, err
+}
+
+// AddVolume will call the books.mylibrary.bookshelves.addVolume API to add a volume to a shelf.
+// https://www.googleapis.com/books/v1/mylibrary/bookshelves/{shelf}/addVolume
+func (v *GoogleShelvesService) AddVolume(shelf ShelfID, volumeID string, opt *ShelfVolumeOptions) (*Response, error) {
+	return v.AddVolumeContext(context.Background(), shelf, volumeID, opt)
+}
+
+// AddVolumeContext is like AddVolume but takes a context.
+func (v *GoogleShelvesService) AddVolumeContext(ctx context.Context, shelf ShelfID, volumeID string, opt *ShelfVolumeOptions) (*Response, error) {
+	if volumeID == "" {
+		return nil, errors.New("volumeID is a required field")
+	}
+
+	return v.mutate(ctx, shelf, "addVolume", &shelfVolumeParams{VolumeID: volumeID}, opt)
+}
+
+// RemoveVolume will call the books.mylibrary.bookshelves.removeVolume API to remove a volume from a shelf.
+// https://www.googleapis.com/books/v1/mylibrary/bookshelves/{shelf}/removeVolume
+func (v *GoogleShelvesService) RemoveVolume(shelf ShelfID, volumeID string, opt *ShelfVolumeOptions) (*Response, error) {
+	return v.RemoveVolumeContext(context.Background(), shelf, volumeID, opt)
+}
+
+// RemoveVolumeContext is like RemoveVolume but takes a context.
+func (v *GoogleShelvesService) RemoveVolumeContext(ctx context.Context, shelf ShelfID, volumeID string, opt *ShelfVolumeOptions) (*Response, error) {
+	if volumeID == "" {
+		return nil, errors.New("volumeID is a required field")
+	}
+
+	return v.mutate(ctx, shelf, "removeVolume", &shelfVolumeParams{VolumeID: volumeID}, opt)
+}
+
+// MoveVolume will call the books.mylibrary.bookshelves.moveVolume API to move a volume within a shelf.
+// position is the zero based index the volume is moved to.
+// https://www.googleapis.com/books/v1/mylibrary/bookshelves/{shelf}/moveVolume
+func (v *GoogleShelvesService) MoveVolume(shelf ShelfID, volumeID string, position int, opt *ShelfVolumeOptions) (*Response, error) {
+	return v.MoveVolumeContext(context.Background(), shelf, volumeID, position, opt)
+}
+
+// MoveVolumeContext is like MoveVolume but takes a context.
+func (v *GoogleShelvesService) MoveVolumeContext(ctx context.Context, shelf ShelfID, volumeID string, position int, opt *ShelfVolumeOptions) (*Response, error) {
+	if volumeID == "" {
+		return nil, errors.New("volumeID is a required field")
+	}
+	if position < 0 {
+		return nil, errors.New("position must not be negative")
+	}
+
+	return v.mutate(ctx, shelf, "moveVolume", &shelfVolumeParams{VolumeID: volumeID, VolumePosition: Int(position)}, opt)
+}
+
+// ClearVolumes will call the books.mylibrary.bookshelves.clearVolumes API to remove every volume from a shelf.
+// https://www.googleapis.com/books/v1/mylibrary/bookshelves/{shelf}/clearVolumes
+func (v *GoogleShelvesService) ClearVolumes(shelf ShelfID, opt *ShelfVolumeOptions) (*Response, error) {
+	return v.ClearVolumesContext(context.Background(), shelf, opt)
+}
+
+// ClearVolumesContext is like ClearVolumes but takes a context.
+func (v *GoogleShelvesService) ClearVolumesContext(ctx context.Context, shelf ShelfID, opt *ShelfVolumeOptions) (*Response, error) {
+	return v.mutate(ctx, shelf, "clearVolumes", &shelfVolumeParams{}, opt)
+}
+
+// mutate sends the bookshelf volume mutation named by action for shelf.
+func (v *GoogleShelvesService) mutate(ctx context.Context, shelf ShelfID, action string, params *shelfVolumeParams, opt *ShelfVolumeOptions) (*Response, error) {
+	if err := shelf.validate(); err != nil {
+		return nil, err
+	}
+
+	ctx = withOperation(ctx, Operation{
+		Service:  "Shelves",
+		Name:     "books.mylibrary.bookshelves." + action,
+		Shelf:    &shelf,
+		VolumeID: params.VolumeID,
+	})
+
+	if opt != nil {
+		params.ShelfVolumeOptions = *opt
+	}
+
+	url := fmt.Sprintf("mylibrary/bookshelves/%d/%s", shelf, action)
+	url, err := addOptions(url, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := v.client.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.client.Do(req, nil)
+}
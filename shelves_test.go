@@ -1,10 +1,12 @@
 package books
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestShelvesList(t *testing.T) {
@@ -23,7 +25,7 @@ func TestShelvesList(t *testing.T) {
 		t.Errorf("List() returned an error: %v", err)
 	}
 
-	expected := []Shelf{{ID: Int(7), Title: String("My Google eBooks"), VolumeCount: Int(13)}, {ID: Int(1), Title: String("Purchased"), VolumeCount: Int(11)}}
+	expected := []Shelf{{ID: shelfID(7), Title: String("My Google eBooks"), VolumeCount: Int(13)}, {ID: shelfID(1), Title: String("Purchased"), VolumeCount: Int(11)}}
 
 	if !reflect.DeepEqual(list, expected) {
 		t.Errorf("List() returned %+v, expected %+v", list, expected)
@@ -47,3 +49,240 @@ func TestShelvesList_badBody(t *testing.T) {
 		t.Errorf("List() Expected Status code got %v, want %v", got, want)
 	}
 }
+
+func TestShelvesAddVolume(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/mylibrary/bookshelves/4/addVolume", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"volumeId": "VN2jCgAAAEAJ", "reason": "ONBOARDING", "source": "ge-web-app1"})
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	opts := &ShelfVolumeOptions{Reason: "ONBOARDING", Source: "ge-web-app1"}
+	if _, err := client.Shelves.AddVolume(ShelfHaveRead, "VN2jCgAAAEAJ", opts); err != nil {
+		t.Errorf("AddVolume() returned an error: %v", err)
+	}
+}
+
+func TestShelvesRemoveVolume(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/mylibrary/bookshelves/3/removeVolume", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"volumeId": "VN2jCgAAAEAJ"})
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.Shelves.RemoveVolume(ShelfReadingNow, "VN2jCgAAAEAJ", nil); err != nil {
+		t.Errorf("RemoveVolume() returned an error: %v", err)
+	}
+}
+
+func TestShelvesMoveVolume(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/mylibrary/bookshelves/0/moveVolume", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"volumeId": "VN2jCgAAAEAJ", "volumePosition": "0", "source": "ge-web-app1"})
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.Shelves.MoveVolume(ShelfFavorites, "VN2jCgAAAEAJ", 0, &ShelfVolumeOptions{Source: "ge-web-app1"}); err != nil {
+		t.Errorf("MoveVolume() returned an error: %v", err)
+	}
+}
+
+func TestShelvesClearVolumes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/mylibrary/bookshelves/2/clearVolumes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{})
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.Shelves.ClearVolumes(ShelfToRead, nil); err != nil {
+		t.Errorf("ClearVolumes() returned an error: %v", err)
+	}
+}
+
+func TestShelvesMutations_errors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	if _, err := client.Shelves.AddVolume(ShelfID(-1), "VN2jCgAAAEAJ", nil); err == nil {
+		t.Error("AddVolume() Expected shelf error.")
+	}
+	if _, err := client.Shelves.RemoveVolume(ShelfReadingNow, "", nil); err == nil {
+		t.Error("RemoveVolume() Expected volumeID error.")
+	}
+	if _, err := client.Shelves.MoveVolume(ShelfReadingNow, "VN2jCgAAAEAJ", -1, nil); err == nil {
+		t.Error("MoveVolume() Expected position error.")
+	}
+
+	mux.HandleFunc("/mylibrary/bookshelves/1099/clearVolumes", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"code":404,"message":"The bookshelf ID could not be found."}}`)
+	})
+
+	// Check that API errors are reported through CheckResponse.
+	resp, err := client.Shelves.ClearVolumes(ShelfID(1099), nil)
+	if _, ok := err.(*ErrorResponse); !ok {
+		t.Errorf("ClearVolumes() Expected *ErrorResponse, got %#v", err)
+	}
+	if got, want := resp.StatusCode, http.StatusNotFound; got != want {
+		t.Errorf("ClearVolumes() Expected Status code got %v, want %v", got, want)
+	}
+}
+
+func TestShelvesGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/mylibrary/bookshelves/4", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":4,"title":"Have read","volumeCount":3}`)
+	})
+
+	shelf, _, err := client.Shelves.Get(ShelfHaveRead, nil)
+	if err != nil {
+		t.Errorf("Get() returned an error: %v", err)
+	}
+
+	expected := &Shelf{ID: shelfID(4), Title: String("Have read"), VolumeCount: Int(3)}
+	if !reflect.DeepEqual(shelf, expected) {
+		t.Errorf("Get() returned %+v, expected %+v", shelf, expected)
+	}
+}
+
+func TestShelvesListForUser(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.token = ""
+	client.apiKey = "api-key"
+
+	mux.HandleFunc("/users/1127/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"key": "api-key"})
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("Authorization = %q, expected none", auth)
+		}
+		fmt.Fprint(w, `{"items":[{"id":0,"title":"Favorites","volumeCount":2}]}`)
+	})
+
+	list, _, err := client.Shelves.ListForUser("1127", nil)
+	if err != nil {
+		t.Errorf("ListForUser() returned an error: %v", err)
+	}
+
+	expected := []Shelf{{ID: shelfID(0), Title: String("Favorites"), VolumeCount: Int(2)}}
+	if !reflect.DeepEqual(list, expected) {
+		t.Errorf("ListForUser() returned %+v, expected %+v", list, expected)
+	}
+}
+
+func TestShelvesGetForUser(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/1127/bookshelves/0", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":0,"title":"Favorites","volumeCount":2}`)
+	})
+
+	shelf, _, err := client.Shelves.GetForUser("1127", ShelfFavorites, nil)
+	if err != nil {
+		t.Errorf("GetForUser() returned an error: %v", err)
+	}
+
+	expected := &Shelf{ID: shelfID(0), Title: String("Favorites"), VolumeCount: Int(2)}
+	if !reflect.DeepEqual(shelf, expected) {
+		t.Errorf("GetForUser() returned %+v, expected %+v", shelf, expected)
+	}
+}
+
+func TestShelvesGet_requiredFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	if _, _, err := client.Shelves.Get(ShelfID(-1), nil); err == nil {
+		t.Error("Get() Expected shelf error.")
+	}
+	if _, _, err := client.Shelves.ListForUser("", nil); err == nil {
+		t.Error("ListForUser() Expected userID error.")
+	}
+	if _, _, err := client.Shelves.GetForUser("1127", ShelfID(-1), nil); err == nil {
+		t.Error("GetForUser() Expected shelf error.")
+	}
+}
+
+func TestShelvesList_golden(t *testing.T) {
+	setup()
+	defer teardown()
+
+	golden := loadFixture(t, "shelves_list.json")
+	mux.HandleFunc("/mylibrary/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(golden)
+	})
+
+	list, _, err := client.Shelves.List(nil)
+	if err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+
+	var root struct {
+		Items json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(golden, &root); err != nil {
+		t.Fatalf("json.Unmarshal(): %v", err)
+	}
+	testJSONRoundTrip(t, root.Items, list)
+
+	favorites := list[0]
+	if got := *favorites.ID; got != ShelfFavorites {
+		t.Errorf("ID = %v, expected %v", got, ShelfFavorites)
+	}
+	updated := time.Date(2016, 5, 13, 21, 39, 50, 456000000, time.UTC)
+	if !favorites.Updated.Equal(updated) {
+		t.Errorf("Updated = %v, expected %v", favorites.Updated, updated)
+	}
+	if got, expected := *favorites.Access, "PUBLIC"; got != expected {
+		t.Errorf("Access = %v, expected %v", got, expected)
+	}
+}
+
+// shelfID returns a pointer to id.
+func shelfID(id ShelfID) *ShelfID { return &id }
+
+func TestShelvesGet_listedShelf(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/mylibrary/bookshelves", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items":[{"id":1001,"title":"Gophers"}]}`)
+	})
+	mux.HandleFunc("/mylibrary/bookshelves/1001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":1001,"title":"Gophers"}`)
+	})
+
+	shelves, _, err := client.Shelves.List(nil)
+	if err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+
+	// A listed shelf is passed back as is.
+	shelf, _, err := client.Shelves.Get(*shelves[0].ID, nil)
+	if err != nil {
+		t.Fatalf("Get() returned an error: %v", err)
+	}
+	if !reflect.DeepEqual(shelf, &shelves[0]) {
+		t.Errorf("Get() returned %+v, expected %+v", shelf, shelves[0])
+	}
+}
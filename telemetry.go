@@ -0,0 +1,170 @@
+package books
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Attribute keys set on spans and metrics by Instrument.
+const (
+	AttrOperation        = "books.operation"
+	AttrVolumeID         = "books.volume_id"
+	AttrShelf            = "books.shelf"
+	AttrPageTokenPresent = "books.page_token.present"
+	AttrResultCount      = "books.result.count"
+	AttrStatusCode       = "http.status_code"
+	AttrErrorReason      = "books.error.reason"
+)
+
+// Attribute is a key-value pair describing a span or a measurement. Value is a string, an int or a bool.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Tracer starts spans. It has the shape of an OpenTelemetry tracer, so that one can be adapted to it in a few
+// lines.
+type Tracer interface {
+	// Start starts a span named name, as a child of any span in ctx, and returns a context carrying it.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Metrics records measurements of API operations. It can be backed by OpenTelemetry or Prometheus instruments.
+type Metrics interface {
+	// RecordLatency adds the duration of an operation to a latency histogram.
+	RecordLatency(ctx context.Context, operation string, d time.Duration, attrs ...Attribute)
+
+	// IncErrors increments the error counter of an operation.
+	IncErrors(ctx context.Context, operation string, attrs ...Attribute)
+}
+
+// Instrument returns a middleware creating a span per API operation with tracer, and recording its latency and
+// errors with metrics. Either of them may be nil.
+//
+//	client, err := books.New(httpClient, books.SetMiddleware(books.Instrument(tracer, metrics)))
+//
+// Spans are named after the operation, such as "books.mylibrary.annotations.list". The span is carried by the
+// context of the request, so spans of an instrumented transport are its children.
+func Instrument(tracer Tracer, metrics Metrics) Middleware {
+	return func(next Handler) Handler {
+		return func(call *Call) (*Response, error) {
+			name := call.Operation.Name
+			if name == "" {
+				name = "books.request"
+			}
+
+			ctx := call.Request.Context()
+			var span Span
+			if tracer != nil {
+				ctx, span = tracer.Start(ctx, name)
+				call.Request = call.Request.WithContext(ctx)
+			}
+
+			start := time.Now()
+			resp, err := next(call)
+			latency := time.Since(start)
+
+			attrs := operationAttributes(call, resp, err)
+			if span != nil {
+				span.SetAttributes(attrs...)
+				if err != nil {
+					span.RecordError(err)
+				}
+				span.End()
+			}
+
+			if metrics != nil {
+				metrics.RecordLatency(ctx, name, latency, statusAttributes(resp, err)...)
+				if err != nil {
+					metrics.IncErrors(ctx, name, statusAttributes(resp, err)...)
+				}
+			}
+
+			return resp, err
+		}
+	}
+}
+
+// itemCounter is implemented by the roots of list responses.
+type itemCounter interface {
+	itemCount() int
+}
+
+// operationAttributes describes a finished call.
+func operationAttributes(call *Call, resp *Response, err error) []Attribute {
+	op := call.Operation
+	attrs := []Attribute{{AttrOperation, op.Name}}
+
+	if op.VolumeID != "" {
+		attrs = append(attrs, Attribute{AttrVolumeID, op.VolumeID})
+	}
+	if op.Shelf != nil {
+		attrs = append(attrs, Attribute{AttrShelf, int(*op.Shelf)})
+	}
+	attrs = append(attrs, Attribute{AttrPageTokenPresent, op.PageToken != ""})
+
+	if counter, ok := call.Result.(itemCounter); ok && err == nil {
+		attrs = append(attrs, Attribute{AttrResultCount, counter.itemCount()})
+	}
+
+	return append(attrs, statusAttributes(resp, err)...)
+}
+
+// statusAttributes describes the outcome of a call, with attributes of low cardinality.
+func statusAttributes(resp *Response, err error) []Attribute {
+	var attrs []Attribute
+	if status := responseStatus(resp, err); status != 0 {
+		attrs = append(attrs, Attribute{AttrStatusCode, status})
+	}
+	if err != nil {
+		attrs = append(attrs, Attribute{AttrErrorReason, errorReason(err)})
+	}
+	return attrs
+}
+
+// responseStatus returns the HTTP status of a call, or 0 if no response was received.
+func responseStatus(resp *Response, err error) int {
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		return errResp.Response.StatusCode
+	}
+	if resp != nil && resp.Response != nil {
+		return resp.StatusCode
+	}
+	return 0
+}
+
+// errorReason classifies err for spans and metrics. API errors are described by their reason, or by their
+// status when they have none.
+func errorReason(err error) string {
+	var errResp *ErrorResponse
+	var decodeErr *DecodeError
+	switch {
+	case errors.As(err, &errResp):
+		if reason := errResp.Reason(); reason != "" {
+			return reason
+		}
+		if errResp.Response != nil {
+			return strconv.Itoa(errResp.Response.StatusCode) + " " + http.StatusText(errResp.Response.StatusCode)
+		}
+		return "apiError"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadlineExceeded"
+	case errors.As(err, &decodeErr):
+		return "decodeError"
+	default:
+		return "transportError"
+	}
+}
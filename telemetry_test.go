@@ -0,0 +1,168 @@
+package books
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// memorySpan is a Span recorded by memoryTracer.
+type memorySpan struct {
+	name   string
+	parent *memorySpan
+	attrs  map[string]interface{}
+	err    error
+	ended  bool
+}
+
+func (s *memorySpan) SetAttributes(attrs ...Attribute) {
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *memorySpan) RecordError(err error) { s.err = err }
+func (s *memorySpan) End()                  { s.ended = true }
+
+type spanKey struct{}
+
+// memoryTracer is a Tracer keeping the spans in memory.
+type memoryTracer struct {
+	spans []*memorySpan
+}
+
+func (t *memoryTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	parent, _ := ctx.Value(spanKey{}).(*memorySpan)
+	span := &memorySpan{name: name, parent: parent, attrs: map[string]interface{}{}}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// memoryMetrics is a Metrics keeping the measurements in memory.
+type memoryMetrics struct {
+	latencies map[string][]time.Duration
+	errors    map[string][]string
+}
+
+func newMemoryMetrics() *memoryMetrics {
+	return &memoryMetrics{latencies: map[string][]time.Duration{}, errors: map[string][]string{}}
+}
+
+func (m *memoryMetrics) RecordLatency(ctx context.Context, operation string, d time.Duration, attrs ...Attribute) {
+	m.latencies[operation] = append(m.latencies[operation], d)
+}
+
+func (m *memoryMetrics) IncErrors(ctx context.Context, operation string, attrs ...Attribute) {
+	for _, a := range attrs {
+		if a.Key == AttrErrorReason {
+			m.errors[operation] = append(m.errors[operation], a.Value.(string))
+		}
+	}
+}
+
+func TestInstrument(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tracer, metrics := &memoryTracer{}, newMemoryMetrics()
+	SetMiddleware(Instrument(tracer, metrics), func(next Handler) Handler {
+		return func(call *Call) (*Response, error) {
+			if span, _ := call.Request.Context().Value(spanKey{}).(*memorySpan); span == nil {
+				t.Error("request context does not carry the span")
+			}
+			return next(call)
+		}
+	})(client)
+
+	mux.HandleFunc("/mylibrary/annotations", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items":[{"id":"a1"},{"id":"a2"}],"nextPageToken":"next"}`)
+	})
+
+	parent, root := tracer.Start(context.Background(), "parent")
+	opt := &AnnotationsListOptions{VolumeID: "v1", PageToken: "page"}
+	if _, _, err := client.Annotations.ListContext(parent, opt); err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+
+	if len(tracer.spans) != 2 {
+		t.Fatalf("recorded %d spans, expected 2", len(tracer.spans))
+	}
+	span := tracer.spans[1]
+	if span.name != "books.mylibrary.annotations.list" || span.parent != root || !span.ended || span.err != nil {
+		t.Errorf("span = %+v", span)
+	}
+
+	want := map[string]interface{}{
+		AttrOperation:        "books.mylibrary.annotations.list",
+		AttrVolumeID:         "v1",
+		AttrPageTokenPresent: true,
+		AttrResultCount:      2,
+		AttrStatusCode:       200,
+	}
+	if !reflect.DeepEqual(span.attrs, want) {
+		t.Errorf("span attributes = %v, expected %v", span.attrs, want)
+	}
+
+	if got := len(metrics.latencies["books.mylibrary.annotations.list"]); got != 1 {
+		t.Errorf("recorded %d latencies, expected 1", got)
+	}
+	if len(metrics.errors) != 0 {
+		t.Errorf("recorded errors %v, expected none", metrics.errors)
+	}
+}
+
+func TestInstrument_error(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tracer, metrics := &memoryTracer{}, newMemoryMetrics()
+	SetMiddleware(Instrument(tracer, metrics))(client)
+
+	mux.HandleFunc("/mylibrary/bookshelves/3", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":{"code":403,"message":"Rate Limit Exceeded","errors":[{"reason":"rateLimitExceeded"}]}}`)
+	})
+
+	if _, _, err := client.Shelves.Get(ShelfReadingNow, nil); err == nil {
+		t.Fatal("Get() returned no error")
+	}
+
+	span := tracer.spans[0]
+	if span.attrs[AttrShelf] != int(ShelfReadingNow) || span.attrs[AttrStatusCode] != 403 ||
+		span.attrs[AttrErrorReason] != "rateLimitExceeded" || span.attrs[AttrPageTokenPresent] != false {
+		t.Errorf("span attributes = %v", span.attrs)
+	}
+	if _, ok := span.attrs[AttrResultCount]; ok {
+		t.Error("result count set on a failed call")
+	}
+	if !IsRateLimited(span.err) {
+		t.Errorf("span error = %v, expected the API error", span.err)
+	}
+
+	want := map[string][]string{"books.mylibrary.bookshelves.get": {"rateLimitExceeded"}}
+	if !reflect.DeepEqual(metrics.errors, want) {
+		t.Errorf("errors = %v, expected %v", metrics.errors, want)
+	}
+}
+
+func TestErrorReason(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{&ErrorResponse{Response: &http.Response{StatusCode: 404}}, "404 Not Found"},
+		{fmt.Errorf("wrapped: %w", context.Canceled), "canceled"},
+		{context.DeadlineExceeded, "deadlineExceeded"},
+		{&DecodeError{Err: fmt.Errorf("bad")}, "decodeError"},
+		{fmt.Errorf("connection refused"), "transportError"},
+	}
+
+	for _, tt := range tests {
+		if got := errorReason(tt.err); got != tt.want {
+			t.Errorf("errorReason(%v) = %q, expected %q", tt.err, got, tt.want)
+		}
+	}
+}
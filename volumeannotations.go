@@ -0,0 +1,178 @@
+package books
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// VolumeAnnotationsService defines the behavior required by types that want to implement a new VolumeAnnotation
+// type.
+type VolumeAnnotationsService interface {
+	List(string, string, string, *VolumeAnnotationsListOptions) ([]VolumeAnnotation, *Response, error)
+	ListContext(context.Context, string, string, string, *VolumeAnnotationsListOptions) ([]VolumeAnnotation, *Response, error)
+	Get(string, string, string, *VolumeAnnotationGetOptions) (*VolumeAnnotation, *Response, error)
+	GetContext(context.Context, string, string, string, *VolumeAnnotationGetOptions) (*VolumeAnnotation, *Response, error)
+}
+
+// GoogleVolumeAnnotationsService implements the VolumeAnnotationsService interface.
+type GoogleVolumeAnnotationsService struct {
+	client *Client
+}
+
+// VolumeAnnotation represents a Google Book Volumeannotation resource, an annotation of a publisher or shared
+// layer. Its payload is fetched with the AnnotationDataService, using AnnotationDataID.
+// https://developers.google.com/books/docs/v1/reference/layers/volumeAnnotations#resource
+type VolumeAnnotation struct {
+	Kind               *string           `json:"kind,omitempty"`
+	ID                 *string           `json:"id,omitempty"`
+	SelfLink           *string           `json:"selfLink,omitempty"`
+	VolumeID           *string           `json:"volumeId,omitempty"`
+	LayerID            *string           `json:"layerId,omitempty"`
+	AnnotationType     *string           `json:"annotationType,omitempty"`
+	AnnotationDataID   *string           `json:"annotationDataId,omitempty"`
+	AnnotationDataLink *string           `json:"annotationDataLink,omitempty"`
+	ContentRanges      *AnnotationRanges `json:"contentRanges,omitempty"`
+	Data               *string           `json:"data,omitempty"`
+	Deleted            *bool             `json:"deleted,omitempty"`
+	PageIDs            []string          `json:"pageIds,omitempty"`
+	SelectedText       *string           `json:"selectedText,omitempty"`
+	Updated            *time.Time        `json:"updated,omitempty"`
+}
+
+type volumeAnnotationsRoot struct {
+	Kind          *string            `json:"kind,omitempty"`
+	TotalItems    *int               `json:"totalItems,omitempty"`
+	NextPageToken *string            `json:"nextPageToken,omitempty"`
+	Version       *string            `json:"version,omitempty"`
+	Annotations   []VolumeAnnotation `json:"items,omitempty"`
+}
+
+func (r *volumeAnnotationsRoot) itemCount() int { return len(r.Annotations) }
+
+// VolumeAnnotationsListOptions specifies the optional parameters for books.layers.volumeAnnotations.list.
+// The start and end positions and offsets restrict the annotations to a range of the volume.
+type VolumeAnnotationsListOptions struct {
+	StartPosition            string    `url:"startPosition,omitempty"`
+	StartOffset              string    `url:"startOffset,omitempty"`
+	EndPosition              string    `url:"endPosition,omitempty"`
+	EndOffset                string    `url:"endOffset,omitempty"`
+	UpdatedMin               time.Time `url:"updatedMin,omitempty"`
+	UpdatedMax               time.Time `url:"updatedMax,omitempty"`
+	Locale                   string    `url:"locale,omitempty"`
+	ShowDeleted              bool      `url:"showDeleted,omitempty"`
+	VolumeAnnotationsVersion string    `url:"volumeAnnotationsVersion,omitempty"`
+	MaxResults               int       `url:"maxResults,omitempty"`
+	PageToken                string    `url:"pageToken,omitempty"`
+	Source                   string    `url:"source,omitempty"`
+}
+
+// volumeAnnotationsListParams specifies the parameters sent to books.layers.volumeAnnotations.list.
+type volumeAnnotationsListParams struct {
+	ContentVersion string `url:"contentVersion"`
+	VolumeAnnotationsListOptions
+}
+
+// VolumeAnnotationGetOptions specifies the optional parameters for books.layers.volumeAnnotations.get.
+type VolumeAnnotationGetOptions struct {
+	Locale string `url:"locale,omitempty"`
+	Source string `url:"source,omitempty"`
+}
+
+// List will call the books.layers.volumeAnnotations.list API to list the annotations of a layer, for the version
+// contentVersion of the volume content.
+// https://www.googleapis.com/books/v1/volumes/{volumeId}/layers/{layerId}
+func (l *GoogleVolumeAnnotationsService) List(volumeID string, layerID string, contentVersion string, opt *VolumeAnnotationsListOptions) ([]VolumeAnnotation, *Response, error) {
+	return l.ListContext(context.Background(), volumeID, layerID, contentVersion, opt)
+}
+
+// ListContext is like List but takes a context.
+func (l *GoogleVolumeAnnotationsService) ListContext(ctx context.Context, volumeID string, layerID string, contentVersion string, opt *VolumeAnnotationsListOptions) ([]VolumeAnnotation, *Response, error) {
+	if volumeID == "" {
+		return nil, nil, errors.New("volumeID is a required field")
+	}
+	if layerID == "" {
+		return nil, nil, errors.New("layerID is a required field")
+	}
+	if contentVersion == "" {
+		return nil, nil, errors.New("contentVersion is a required field")
+	}
+
+	op := Operation{Service: "Layers", Name: "books.layers.volumeAnnotations.list", VolumeID: volumeID}
+	if opt != nil {
+		op.PageToken = opt.PageToken
+	}
+	ctx = withOperation(ctx, op)
+
+	params := &volumeAnnotationsListParams{ContentVersion: contentVersion}
+	if opt != nil {
+		params.VolumeAnnotationsListOptions = *opt
+	}
+
+	url := fmt.Sprintf("volumes/%s/layers/%s", volumeID, layerID)
+	url, err := addOptions(url, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := l.client.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(volumeAnnotationsRoot)
+	resp, err := l.client.Do(req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if n := root.NextPageToken; n != nil {
+		resp.NextPageToken = *n
+	}
+	if t := root.TotalItems; t != nil {
+		resp.TotalItems = *t
+	}
+
+	return root.Annotations, resp, err
+}
+
+// Get will call the books.layers.volumeAnnotations.get API to get a single annotation of a layer.
+// https://www.googleapis.com/books/v1/volumes/{volumeId}/layers/{layerId}/annotations/{annotationId}
+func (l *GoogleVolumeAnnotationsService) Get(volumeID string, layerID string, annotationID string, opt *VolumeAnnotationGetOptions) (*VolumeAnnotation, *Response, error) {
+	return l.GetContext(context.Background(), volumeID, layerID, annotationID, opt)
+}
+
+// GetContext is like Get but takes a context.
+func (l *GoogleVolumeAnnotationsService) GetContext(ctx context.Context, volumeID string, layerID string, annotationID string, opt *VolumeAnnotationGetOptions) (*VolumeAnnotation, *Response, error) {
+	if volumeID == "" {
+		return nil, nil, errors.New("volumeID is a required field")
+	}
+	if layerID == "" {
+		return nil, nil, errors.New("layerID is a required field")
+	}
+	if annotationID == "" {
+		return nil, nil, errors.New("annotationID is a required field")
+	}
+
+	ctx = withOperation(ctx, Operation{Service: "Layers", Name: "books.layers.volumeAnnotations.get", VolumeID: volumeID, AnnotationID: annotationID})
+
+	url := fmt.Sprintf("volumes/%s/layers/%s/annotations/%s", volumeID, layerID, annotationID)
+	url, err := addOptions(url, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := l.client.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotation := new(VolumeAnnotation)
+	resp, err := l.client.Do(req, annotation)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return annotation, resp, err
+}
@@ -0,0 +1,111 @@
+package books
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestVolumeAnnotationsList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/volumes/VN2jCgAAAEAJ/layers/dictionary", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"contentVersion": "full-1.0.0",
+			"startPosition":  "GBS.PA23.w.1.0.0",
+			"startOffset":    "0",
+			"endPosition":    "GBS.PA24.w.1.0.0",
+			"endOffset":      "120",
+			"updatedMax":     "2016-03-01T00:00:00Z",
+			"locale":         "en",
+			"showDeleted":    "true",
+			"pageToken":      "CgRkaWN0",
+		})
+		fmt.Fprint(w, `{
+			"totalItems": 2,
+			"nextPageToken": "CgRkaWN1",
+			"version": "4",
+			"items": [{
+				"id": "a1",
+				"annotationType": "dictionary",
+				"annotationDataId": "dict-goroutine",
+				"selectedText": "concurrent",
+				"contentRanges": {"gbTextRange": {"startPosition": "GBS.PA23.w.1.0.0", "startOffset": "10"}},
+				"updated": "2016-02-11T09:27:03.851Z"
+			}]
+		}`)
+	})
+
+	opts := &VolumeAnnotationsListOptions{
+		StartPosition: "GBS.PA23.w.1.0.0",
+		StartOffset:   "0",
+		EndPosition:   "GBS.PA24.w.1.0.0",
+		EndOffset:     "120",
+		UpdatedMax:    time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC),
+		Locale:        "en",
+		ShowDeleted:   true,
+		PageToken:     "CgRkaWN0",
+	}
+	annotations, resp, err := client.Layers.VolumeAnnotations().List("VN2jCgAAAEAJ", "dictionary", "full-1.0.0", opts)
+	if err != nil {
+		t.Fatalf("List() returned an error: %v", err)
+	}
+
+	updated := time.Date(2016, 2, 11, 9, 27, 3, 851000000, time.UTC)
+	expected := []VolumeAnnotation{{
+		ID:               String("a1"),
+		AnnotationType:   String("dictionary"),
+		AnnotationDataID: String("dict-goroutine"),
+		SelectedText:     String("concurrent"),
+		ContentRanges: &AnnotationRanges{
+			GbTextRange: &AnnotationRange{StartPosition: String("GBS.PA23.w.1.0.0"), StartOffset: String("10")},
+		},
+		Updated: &updated,
+	}}
+	if !reflect.DeepEqual(annotations, expected) {
+		t.Errorf("List() returned %+v, expected %+v", annotations, expected)
+	}
+	if resp.NextPageToken != "CgRkaWN1" || resp.TotalItems != 2 {
+		t.Errorf("NextPageToken = %q, TotalItems = %d", resp.NextPageToken, resp.TotalItems)
+	}
+}
+
+func TestVolumeAnnotationsGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/volumes/VN2jCgAAAEAJ/layers/geo/annotations/a2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"locale": "en"})
+		fmt.Fprint(w, `{"id":"a2","layerId":"geo","pageIds":["PA7"]}`)
+	})
+
+	annotation, _, err := client.Layers.VolumeAnnotations().Get("VN2jCgAAAEAJ", "geo", "a2", &VolumeAnnotationGetOptions{Locale: "en"})
+	if err != nil {
+		t.Fatalf("Get() returned an error: %v", err)
+	}
+
+	expected := &VolumeAnnotation{ID: String("a2"), LayerID: String("geo"), PageIDs: []string{"PA7"}}
+	if !reflect.DeepEqual(annotation, expected) {
+		t.Errorf("Get() returned %+v, expected %+v", annotation, expected)
+	}
+}
+
+func TestVolumeAnnotations_requiredFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	if _, _, err := client.Layers.VolumeAnnotations().List("", "geo", "full-1.0.0", nil); err == nil {
+		t.Error("List() without volumeID returned no error")
+	}
+	if _, _, err := client.Layers.VolumeAnnotations().List("VN2jCgAAAEAJ", "geo", "", nil); err == nil {
+		t.Error("List() without contentVersion returned no error")
+	}
+	if _, _, err := client.Layers.VolumeAnnotations().Get("VN2jCgAAAEAJ", "geo", "", nil); err == nil {
+		t.Error("Get() without annotationID returned no error")
+	}
+}
@@ -1,13 +1,24 @@
 package books
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
 )
 
 // VolumesService defines the behavior required by types that want to implement a new Volumes type.
 type VolumesService interface {
-	List(string, *VolumesListOptions) ([]Volume, *Response, error)
+	List(ShelfID, *VolumesListOptions) ([]Volume, *Response, error)
+	ListContext(context.Context, ShelfID, *VolumesListOptions) ([]Volume, *Response, error)
+	Search(*VolumesSearchOptions) ([]Volume, *Response, error)
+	SearchContext(context.Context, *VolumesSearchOptions) ([]Volume, *Response, error)
+	Get(string, *VolumeGetOptions) (*Volume, *Response, error)
+	GetContext(context.Context, string, *VolumeGetOptions) (*Volume, *Response, error)
+	ListForUser(string, ShelfID, *VolumesListOptions) ([]Volume, *Response, error)
+	ListForUserContext(context.Context, string, ShelfID, *VolumesListOptions) ([]Volume, *Response, error)
 }
 
 // GoogleVolumesService implements the VolumesService interface.
@@ -16,53 +27,436 @@ type GoogleVolumesService struct {
 }
 
 // Volume represents a Google Book Volume resource.
+// https://developers.google.com/books/docs/v1/reference/volumes#resource
 type Volume struct {
-	ID   *string     `json:"id,omitempty"`
-	Info *VolumeInfo `json:"volumeInfo,omitempty"`
+	Kind       *string           `json:"kind,omitempty"`
+	ID         *string           `json:"id,omitempty"`
+	ETag       *string           `json:"etag,omitempty"`
+	SelfLink   *string           `json:"selfLink,omitempty"`
+	Info       *VolumeInfo       `json:"volumeInfo,omitempty"`
+	UserInfo   *VolumeUserInfo   `json:"userInfo,omitempty"`
+	SaleInfo   *VolumeSaleInfo   `json:"saleInfo,omitempty"`
+	AccessInfo *VolumeAccessInfo `json:"accessInfo,omitempty"`
+	LayerInfo  *VolumeLayerInfo  `json:"layerInfo,omitempty"`
+	SearchInfo *VolumeSearchInfo `json:"searchInfo,omitempty"`
 }
 
 // VolumeInfo represents a google.book.volumes.volumeInfo
 type VolumeInfo struct {
-	Title          *string           `json:"title,omitempty"`
-	ContentVersion *string           `json:"contentVersion,omitempty"`
-	ImageLinks     *VolumeImageLinks `json:"imageLinks,omitempty"`
+	Title               *string              `json:"title,omitempty"`
+	Subtitle            *string              `json:"subtitle,omitempty"`
+	Authors             []string             `json:"authors,omitempty"`
+	Publisher           *string              `json:"publisher,omitempty"`
+	PublishedDate       *string              `json:"publishedDate,omitempty"`
+	Description         *string              `json:"description,omitempty"`
+	IndustryIdentifiers []IndustryIdentifier `json:"industryIdentifiers,omitempty"`
+	ReadingModes        *VolumeReadingModes  `json:"readingModes,omitempty"`
+	PageCount           *int                 `json:"pageCount,omitempty"`
+	PrintedPageCount    *int                 `json:"printedPageCount,omitempty"`
+	Dimensions          *VolumeDimensions    `json:"dimensions,omitempty"`
+	PrintType           *string              `json:"printType,omitempty"`
+	MainCategory        *string              `json:"mainCategory,omitempty"`
+	Categories          []string             `json:"categories,omitempty"`
+	AverageRating       *float64             `json:"averageRating,omitempty"`
+	RatingsCount        *int                 `json:"ratingsCount,omitempty"`
+	MaturityRating      *string              `json:"maturityRating,omitempty"`
+	AllowAnonLogging    *bool                `json:"allowAnonLogging,omitempty"`
+	ContentVersion      *string              `json:"contentVersion,omitempty"`
+	ImageLinks          *VolumeImageLinks    `json:"imageLinks,omitempty"`
+	Language            *string              `json:"language,omitempty"`
+	PreviewLink         *string              `json:"previewLink,omitempty"`
+	InfoLink            *string              `json:"infoLink,omitempty"`
+	CanonicalVolumeLink *string              `json:"canonicalVolumeLink,omitempty"`
+}
+
+// IndustryIdentifier is an industry standard identifier for a volume, such as an ISBN.
+type IndustryIdentifier struct {
+	// Type is one of ISBN_10, ISBN_13, ISSN or OTHER.
+	Type       *string `json:"type,omitempty"`
+	Identifier *string `json:"identifier,omitempty"`
+}
+
+// VolumeReadingModes reports which reading modes are available for the volume.
+type VolumeReadingModes struct {
+	Text  *bool `json:"text,omitempty"`
+	Image *bool `json:"image,omitempty"`
+}
+
+// VolumeDimensions holds the physical dimensions of the volume, for example "24.00 cm".
+type VolumeDimensions struct {
+	Height    *string `json:"height,omitempty"`
+	Width     *string `json:"width,omitempty"`
+	Thickness *string `json:"thickness,omitempty"`
 }
 
 // VolumeImageLinks holds image information from the volume.
 type VolumeImageLinks struct {
 	SmallThumbnail *string `json:"smallThumbnail,omitempty"`
 	Thumbnail      *string `json:"thumbnail,omitempty"`
+	Small          *string `json:"small,omitempty"`
+	Medium         *string `json:"medium,omitempty"`
+	Large          *string `json:"large,omitempty"`
+	ExtraLarge     *string `json:"extraLarge,omitempty"`
+}
+
+// VolumeUserInfo holds user specific information related to the volume.
+type VolumeUserInfo struct {
+	Review          *Review          `json:"review,omitempty"`
+	ReadingPosition *ReadingPosition `json:"readingPosition,omitempty"`
+	IsPurchased     *bool            `json:"isPurchased,omitempty"`
+	IsPreordered    *bool            `json:"isPreordered,omitempty"`
+	IsInMyBooks     *bool            `json:"isInMyBooks,omitempty"`
+	IsUploaded      *bool            `json:"isUploaded,omitempty"`
+	RentalState     *string          `json:"rentalState,omitempty"`
+	RentalPeriod    *RentalPeriod    `json:"rentalPeriod,omitempty"`
+	Updated         *time.Time       `json:"updated,omitempty"`
+	Copy            *UserCopy        `json:"copy,omitempty"`
+}
+
+// Review represents a Google Book Review resource.
+type Review struct {
+	Kind        *string       `json:"kind,omitempty"`
+	VolumeID    *string       `json:"volumeId,omitempty"`
+	Rating      *string       `json:"rating,omitempty"`
+	Title       *string       `json:"title,omitempty"`
+	Content     *string       `json:"content,omitempty"`
+	Date        *string       `json:"date,omitempty"`
+	Type        *string       `json:"type,omitempty"`
+	FullTextURL *string       `json:"fullTextUrl,omitempty"`
+	Author      *ReviewAuthor `json:"author,omitempty"`
+	Source      *ReviewSource `json:"source,omitempty"`
+}
+
+// ReviewAuthor is the author of a review.
+type ReviewAuthor struct {
+	DisplayName *string `json:"displayName,omitempty"`
+}
+
+// ReviewSource is the site a review was taken from.
+type ReviewSource struct {
+	URL              *string `json:"url,omitempty"`
+	Description      *string `json:"description,omitempty"`
+	ExtraDescription *string `json:"extraDescription,omitempty"`
+}
+
+// RentalPeriod is the period of a rental, in seconds since the epoch.
+type RentalPeriod struct {
+	StartUtcSec *string `json:"startUtcSec,omitempty"`
+	EndUtcSec   *string `json:"endUtcSec,omitempty"`
+}
+
+// UserCopy reports the copy allowance the user has left on the volume.
+type UserCopy struct {
+	RemainingCharacterCount *int       `json:"remainingCharacterCount,omitempty"`
+	AllowedCharacterCount   *int       `json:"allowedCharacterCount,omitempty"`
+	LimitType               *string    `json:"limitType,omitempty"`
+	Updated                 *time.Time `json:"updated,omitempty"`
+}
+
+// VolumeSaleInfo holds information about the availability of the volume for sale.
+type VolumeSaleInfo struct {
+	Country     *string    `json:"country,omitempty"`
+	Saleability *string    `json:"saleability,omitempty"`
+	OnSaleDate  *time.Time `json:"onSaleDate,omitempty"`
+	IsEbook     *bool      `json:"isEbook,omitempty"`
+	ListPrice   *Price     `json:"listPrice,omitempty"`
+	RetailPrice *Price     `json:"retailPrice,omitempty"`
+	BuyLink     *string    `json:"buyLink,omitempty"`
+	Offers      []Offer    `json:"offers,omitempty"`
+}
+
+// Price is an amount in the given currency.
+type Price struct {
+	Amount       *float64 `json:"amount,omitempty"`
+	CurrencyCode *string  `json:"currencyCode,omitempty"`
+}
+
+// Offer is a sale offer for the volume.
+type Offer struct {
+	FinskyOfferType *int            `json:"finskyOfferType,omitempty"`
+	ListPrice       *OfferPrice     `json:"listPrice,omitempty"`
+	RetailPrice     *OfferPrice     `json:"retailPrice,omitempty"`
+	RentalDuration  *RentalDuration `json:"rentalDuration,omitempty"`
+	Giftable        *bool           `json:"giftable,omitempty"`
+}
+
+// OfferPrice is an amount in micros in the given currency.
+type OfferPrice struct {
+	AmountInMicros *float64 `json:"amountInMicros,omitempty"`
+	CurrencyCode   *string  `json:"currencyCode,omitempty"`
+}
+
+// RentalDuration is the length of a rental offer.
+type RentalDuration struct {
+	Unit  *string  `json:"unit,omitempty"`
+	Count *float64 `json:"count,omitempty"`
+}
+
+// VolumeAccessInfo holds information about the user's access to the volume.
+type VolumeAccessInfo struct {
+	Country                          *string                    `json:"country,omitempty"`
+	Viewability                      *string                    `json:"viewability,omitempty"`
+	Embeddable                       *bool                      `json:"embeddable,omitempty"`
+	PublicDomain                     *bool                      `json:"publicDomain,omitempty"`
+	TextToSpeechPermission           *string                    `json:"textToSpeechPermission,omitempty"`
+	Epub                             *FormatAccess              `json:"epub,omitempty"`
+	Pdf                              *FormatAccess              `json:"pdf,omitempty"`
+	WebReaderLink                    *string                    `json:"webReaderLink,omitempty"`
+	AccessViewStatus                 *string                    `json:"accessViewStatus,omitempty"`
+	QuoteSharingAllowed              *bool                      `json:"quoteSharingAllowed,omitempty"`
+	DownloadAccess                   *DownloadAccessRestriction `json:"downloadAccess,omitempty"`
+	ViewOrderURL                     *string                    `json:"viewOrderUrl,omitempty"`
+	ExplicitOfflineLicenseManagement *bool                      `json:"explicitOfflineLicenseManagement,omitempty"`
+}
+
+// FormatAccess reports the availability of the volume in a given format, epub or pdf.
+type FormatAccess struct {
+	IsAvailable  *bool   `json:"isAvailable,omitempty"`
+	DownloadLink *string `json:"downloadLink,omitempty"`
+	AcsTokenLink *string `json:"acsTokenLink,omitempty"`
+}
+
+// DownloadAccessRestriction represents a Google Book DownloadAccessRestriction resource.
+type DownloadAccessRestriction struct {
+	Kind               *string `json:"kind,omitempty"`
+	VolumeID           *string `json:"volumeId,omitempty"`
+	Restricted         *bool   `json:"restricted,omitempty"`
+	DeviceAllowed      *bool   `json:"deviceAllowed,omitempty"`
+	JustAcquired       *bool   `json:"justAcquired,omitempty"`
+	MaxDownloadDevices *int    `json:"maxDownloadDevices,omitempty"`
+	DownloadsAcquired  *int    `json:"downloadsAcquired,omitempty"`
+	Nonce              *string `json:"nonce,omitempty"`
+	Source             *string `json:"source,omitempty"`
+	ReasonCode         *string `json:"reasonCode,omitempty"`
+	Message            *string `json:"message,omitempty"`
+	Signature          *string `json:"signature,omitempty"`
+}
+
+// VolumeLayerInfo lists the annotation layers available on the volume.
+type VolumeLayerInfo struct {
+	Layers []VolumeLayer `json:"layers,omitempty"`
+}
+
+// VolumeLayer identifies one annotation layer and its version.
+type VolumeLayer struct {
+	LayerID                  *string `json:"layerId,omitempty"`
+	VolumeAnnotationsVersion *string `json:"volumeAnnotationsVersion,omitempty"`
+}
+
+// VolumeSearchInfo holds search result information related to the volume.
+type VolumeSearchInfo struct {
+	TextSnippet *string `json:"textSnippet,omitempty"`
 }
 
 // volumesRoot represents a response from Google Books API.
 type volumesRoot struct {
+	Kind       *string  `json:"kind,omitempty"`
 	TotalItems int      `json:"totalItems"`
 	Volumes    []Volume `json:"items"`
 }
 
+func (r *volumesRoot) itemCount() int { return len(r.Volumes) }
+
 // VolumesListOptions specifies the optional parameters needed to make API request.
-// books.mylibrary.bookshelves.volumes.list
+// books.mylibrary.bookshelves.volumes.list and books.bookshelves.volumes.list
 type VolumesListOptions struct {
-	Shelf      int    `url:"shelf,omitempty"`
-	MaxResults int    `url:"maxResults,omitempty"`
-	Quey       string `url:"q,omitempty"`
-	Source     string `url:"source,omitempty"`
-	Fields     string `url:"fields, omitempty,omitempty"`
+	Shelf         int    `url:"shelf,omitempty"`
+	MaxResults    int    `url:"maxResults,omitempty"`
+	StartIndex    int    `url:"startIndex,omitempty"`
+	ShowPreorders bool   `url:"showPreorders,omitempty"`
+	Quey          string `url:"q,omitempty"`
+	Source        string `url:"source,omitempty"`
+	Fields        string `url:"fields, omitempty,omitempty"`
+}
+
+// VolumesQuery builds the full-text search string sent as the q parameter of books.volumes.list.
+// Terms is free text; every other non-empty field is added with its keyword prefix, for example
+// VolumesQuery{Terms: "flowers", InAuthor: "keyes"} encodes as "flowers inauthor:keyes".
+// https://developers.google.com/books/docs/v1/using#PerformingSearch
+type VolumesQuery struct {
+	Terms       string
+	InTitle     string
+	InAuthor    string
+	InPublisher string
+	Subject     string
+	ISBN        string
+	LCCN        string
+	OCLC        string
+}
+
+// String returns the query in the format expected by the q parameter.
+func (q *VolumesQuery) String() string {
+	parts := []string{}
+	if t := strings.TrimSpace(q.Terms); t != "" {
+		parts = append(parts, t)
+	}
+
+	keywords := []struct {
+		prefix string
+		value  string
+	}{
+		{"intitle:", q.InTitle},
+		{"inauthor:", q.InAuthor},
+		{"inpublisher:", q.InPublisher},
+		{"subject:", q.Subject},
+		{"isbn:", q.ISBN},
+		{"lccn:", q.LCCN},
+		{"oclc:", q.OCLC},
+	}
+	for _, k := range keywords {
+		v := strings.TrimSpace(k.value)
+		if v == "" {
+			continue
+		}
+		if strings.ContainsAny(v, " \t") {
+			v = `"` + v + `"`
+		}
+		parts = append(parts, k.prefix+v)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// EncodeValues implements query.Encoder so the query is escaped together with the rest of the options.
+func (q *VolumesQuery) EncodeValues(key string, v *url.Values) error {
+	if s := q.String(); s != "" {
+		v.Set(key, s)
+	}
+	return nil
+}
+
+// VolumesFilter restricts search results by volume availability.
+type VolumesFilter string
+
+// Values accepted by the filter parameter.
+const (
+	FilterPartial    VolumesFilter = "partial"
+	FilterFull       VolumesFilter = "full"
+	FilterFreeEbooks VolumesFilter = "free-ebooks"
+	FilterPaidEbooks VolumesFilter = "paid-ebooks"
+	FilterEbooks     VolumesFilter = "ebooks"
+)
+
+// PrintType restricts search results to books or magazines.
+type PrintType string
+
+// Values accepted by the printType parameter.
+const (
+	PrintTypeAll       PrintType = "all"
+	PrintTypeBooks     PrintType = "books"
+	PrintTypeMagazines PrintType = "magazines"
+)
+
+// OrderBy sets the sort order of search results.
+type OrderBy string
+
+// Values accepted by the orderBy parameter.
+const (
+	OrderByRelevance OrderBy = "relevance"
+	OrderByNewest    OrderBy = "newest"
+)
+
+// LibraryRestrict restricts search results to the user's library.
+type LibraryRestrict string
+
+// Values accepted by the libraryRestrict parameter.
+const (
+	LibraryRestrictMyLibrary  LibraryRestrict = "my-library"
+	LibraryRestrictNoRestrict LibraryRestrict = "no-restrict"
+)
+
+// DownloadFormatEpub restricts search results to volumes with an epub download available.
+const DownloadFormatEpub = "epub"
+
+// VolumesSearchOptions specifies the parameters needed to make API request.
+// books.volumes.list
+type VolumesSearchOptions struct {
+	Query           *VolumesQuery   `url:"q,omitempty"`
+	Filter          VolumesFilter   `url:"filter,omitempty"`
+	PrintType       PrintType       `url:"printType,omitempty"`
+	OrderBy         OrderBy         `url:"orderBy,omitempty"`
+	LangRestrict    string          `url:"langRestrict,omitempty"`
+	Download        string          `url:"download,omitempty"`
+	LibraryRestrict LibraryRestrict `url:"libraryRestrict,omitempty"`
+	StartIndex      int             `url:"startIndex,omitempty"`
+	MaxResults      int             `url:"maxResults,omitempty"`
+	Source          string          `url:"source,omitempty"`
+	Fields          string          `url:"fields,omitempty"`
+}
+
+// Projection restricts the volume information returned to a set of predefined fields.
+type Projection string
+
+// Values accepted by the projection parameter.
+const (
+	ProjectionFull Projection = "full"
+	ProjectionLite Projection = "lite"
+)
+
+// VolumeGetOptions specifies the optional parameters needed to make API request.
+// books.volumes.get
+type VolumeGetOptions struct {
+	Projection             Projection `url:"projection,omitempty"`
+	Partner                string     `url:"partner,omitempty"`
+	Source                 string     `url:"source,omitempty"`
+	Country                string     `url:"country,omitempty"`
+	IncludeNonComicsSeries bool       `url:"includeNonComicsSeries,omitempty"`
+	Fields                 string     `url:"fields,omitempty"`
 }
 
 // List will call the books.mylibrary.bookshelves.volumes.list API.
-func (v *GoogleVolumesService) List(volumeID string, opt *VolumesListOptions) ([]Volume, *Response, error) {
-	if volumeID == "" {
-		return nil, nil, errors.New("volumeID is a required field")
+func (v *GoogleVolumesService) List(shelf ShelfID, opt *VolumesListOptions) ([]Volume, *Response, error) {
+	return v.ListContext(context.Background(), shelf, opt)
+}
+
+// ListContext is like List but takes a context.
+func (v *GoogleVolumesService) ListContext(ctx context.Context, shelf ShelfID, opt *VolumesListOptions) ([]Volume, *Response, error) {
+	if err := shelf.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	op := Operation{Service: "Volumes", Name: "books.mylibrary.bookshelves.volumes.list", Shelf: &shelf}
+	if opt != nil {
+		op.StartIndex = opt.StartIndex
 	}
+	ctx = withOperation(ctx, op)
 
-	url := fmt.Sprintf("mylibrary/bookshelves/%s/volumes", volumeID)
+	return v.list(ctx, fmt.Sprintf("mylibrary/bookshelves/%d/volumes", shelf), opt)
+}
+
+// ListForUser will call the books.bookshelves.volumes.list API to list the volumes on a public shelf of
+// another user. It does not require an oauth token, an API key set with SetAPIKey is enough.
+// https://www.googleapis.com/books/v1/users/{userId}/bookshelves/{shelf}/volumes
+func (v *GoogleVolumesService) ListForUser(userID string, shelf ShelfID, opt *VolumesListOptions) ([]Volume, *Response, error) {
+	return v.ListForUserContext(context.Background(), userID, shelf, opt)
+}
+
+// ListForUserContext is like ListForUser but takes a context.
+func (v *GoogleVolumesService) ListForUserContext(ctx context.Context, userID string, shelf ShelfID, opt *VolumesListOptions) ([]Volume, *Response, error) {
+	if userID == "" {
+		return nil, nil, errors.New("userID is a required field")
+	}
+	if err := shelf.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	op := Operation{Service: "Volumes", Name: "books.bookshelves.volumes.list", UserID: userID, Shelf: &shelf}
+	if opt != nil {
+		op.StartIndex = opt.StartIndex
+	}
+	ctx = withOperation(ctx, op)
+
+	return v.list(ctx, fmt.Sprintf("users/%s/bookshelves/%d/volumes", userID, shelf), opt)
+}
+
+// list fetches the volumes listed at url.
+func (v *GoogleVolumesService) list(ctx context.Context, url string, opt interface{}) ([]Volume, *Response, error) {
 	url, err := addOptions(url, opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := v.client.NewRequest("GET", url, nil)
+	req, err := v.client.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -73,5 +467,208 @@ func (v *GoogleVolumesService) List(volumeID string, opt *VolumesListOptions) ([
 		return nil, resp, err
 	}
 
+	resp.TotalItems = root.TotalItems
 	return root.Volumes, resp, err
 }
+
+// Search will call the books.volumes.list API to search the public catalogue.
+// https://www.googleapis.com/books/v1/volumes?q={search terms}
+func (v *GoogleVolumesService) Search(opt *VolumesSearchOptions) ([]Volume, *Response, error) {
+	return v.SearchContext(context.Background(), opt)
+}
+
+// SearchContext is like Search but takes a context.
+func (v *GoogleVolumesService) SearchContext(ctx context.Context, opt *VolumesSearchOptions) ([]Volume, *Response, error) {
+	if opt == nil || opt.Query == nil || opt.Query.String() == "" {
+		return nil, nil, errors.New("query is a required field")
+	}
+
+	op := Operation{Service: "Volumes", Name: "books.volumes.list"}
+	if opt != nil {
+		op.StartIndex = opt.StartIndex
+	}
+	ctx = withOperation(ctx, op)
+
+	return v.list(ctx, "volumes", opt)
+}
+
+// Get will call the books.volumes.get API to retrieve a single volume.
+// https://www.googleapis.com/books/v1/volumes/{volumeId}
+func (v *GoogleVolumesService) Get(volumeID string, opt *VolumeGetOptions) (*Volume, *Response, error) {
+	return v.GetContext(context.Background(), volumeID, opt)
+}
+
+// GetContext is like Get but takes a context.
+func (v *GoogleVolumesService) GetContext(ctx context.Context, volumeID string, opt *VolumeGetOptions) (*Volume, *Response, error) {
+	if volumeID == "" {
+		return nil, nil, errors.New("volumeID is a required field")
+	}
+
+	ctx = withOperation(ctx, Operation{Service: "Volumes", Name: "books.volumes.get", VolumeID: volumeID})
+
+	url := fmt.Sprintf("volumes/%s", volumeID)
+	url, err := addOptions(url, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := v.client.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	volume := new(Volume)
+	resp, err := v.client.Do(req, volume)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return volume, resp, err
+}
+
+// volumesPageFunc fetches the page of volumes starting at startIndex. A maxResults of zero uses the API default.
+type volumesPageFunc func(ctx context.Context, startIndex, maxResults int) ([]Volume, *Response, error)
+
+// VolumesIterator lazily fetches volumes page by page, advancing startIndex until totalItems is reached or an
+// empty page is returned.
+//
+//	it := books.NewVolumesSearchIterator(ctx, client.Volumes, opts, 100)
+//	for it.Next() {
+//		volume := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle err
+//	}
+type VolumesIterator struct {
+	ctx        context.Context
+	fetchPage  volumesPageFunc
+	startIndex int
+	maxResults int
+	limit      int
+
+	page  []Volume
+	index int
+	count int
+	value Volume
+	resp  *Response
+	last  bool
+	err   error
+}
+
+// NewVolumesIterator returns an iterator over the volumes on a shelf of the authenticated user, listed by s
+// with opt. A limit greater than zero stops the iteration after that many volumes.
+func NewVolumesIterator(ctx context.Context, s VolumesService, shelf ShelfID, opt *VolumesListOptions, limit int) *VolumesIterator {
+	o := VolumesListOptions{}
+	if opt != nil {
+		o = *opt
+	}
+
+	return newVolumesIterator(ctx, o.StartIndex, o.MaxResults, limit, func(ctx context.Context, startIndex, maxResults int) ([]Volume, *Response, error) {
+		o.StartIndex, o.MaxResults = startIndex, maxResults
+		return s.ListContext(ctx, shelf, &o)
+	})
+}
+
+// NewUserVolumesIterator returns an iterator over the volumes on a public shelf of another user, listed by s
+// with opt. A limit greater than zero stops the iteration after that many volumes.
+func NewUserVolumesIterator(ctx context.Context, s VolumesService, userID string, shelf ShelfID, opt *VolumesListOptions, limit int) *VolumesIterator {
+	o := VolumesListOptions{}
+	if opt != nil {
+		o = *opt
+	}
+
+	return newVolumesIterator(ctx, o.StartIndex, o.MaxResults, limit, func(ctx context.Context, startIndex, maxResults int) ([]Volume, *Response, error) {
+		o.StartIndex, o.MaxResults = startIndex, maxResults
+		return s.ListForUserContext(ctx, userID, shelf, &o)
+	})
+}
+
+// NewVolumesSearchIterator returns an iterator over the volumes found by s with opt. A limit greater than zero
+// stops the iteration after that many volumes.
+func NewVolumesSearchIterator(ctx context.Context, s VolumesService, opt *VolumesSearchOptions, limit int) *VolumesIterator {
+	o := VolumesSearchOptions{}
+	if opt != nil {
+		o = *opt
+	}
+
+	return newVolumesIterator(ctx, o.StartIndex, o.MaxResults, limit, func(ctx context.Context, startIndex, maxResults int) ([]Volume, *Response, error) {
+		o.StartIndex, o.MaxResults = startIndex, maxResults
+		return s.SearchContext(ctx, &o)
+	})
+}
+
+// newVolumesIterator returns a VolumesIterator that gets its pages from fetchPage.
+func newVolumesIterator(ctx context.Context, startIndex, maxResults, limit int, fetchPage volumesPageFunc) *VolumesIterator {
+	return &VolumesIterator{
+		ctx:        ctx,
+		fetchPage:  fetchPage,
+		startIndex: startIndex,
+		maxResults: maxResults,
+		limit:      limit,
+	}
+}
+
+// Next advances the iterator to the next volume, fetching the next page when needed. It returns false when the
+// iteration is over or an error occurred, which is then reported by Err.
+func (it *VolumesIterator) Next() bool {
+	if it.err != nil || (it.limit > 0 && it.count >= it.limit) {
+		return false
+	}
+
+	for it.index >= len(it.page) {
+		if it.last {
+			return false
+		}
+		if err := it.fetch(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	it.value = it.page[it.index]
+	it.index++
+	it.count++
+	return true
+}
+
+// fetch gets the next page of volumes.
+func (it *VolumesIterator) fetch() error {
+	maxResults := it.maxResults
+	if it.limit > 0 {
+		if remaining := it.limit - it.count; maxResults == 0 || remaining < maxResults {
+			maxResults = remaining
+		}
+	}
+	if maxResults > maxPageSize {
+		maxResults = maxPageSize
+	}
+
+	page, resp, err := it.fetchPage(it.ctx, it.startIndex, maxResults)
+	it.resp = resp
+	if err != nil {
+		return err
+	}
+
+	it.page, it.index = page, 0
+	it.startIndex += len(page)
+
+	// totalItems is missing when the API omits it or Fields leaves it out, then a short page is the last one.
+	switch {
+	case len(page) == 0:
+		it.last = true
+	case resp.TotalItems > 0:
+		it.last = it.startIndex >= resp.TotalItems
+	case maxResults > 0:
+		it.last = len(page) < maxResults
+	}
+	return nil
+}
+
+// Value returns the current volume.
+func (it *VolumesIterator) Value() Volume { return it.value }
+
+// Response returns the response of the page the current volume belongs to.
+func (it *VolumesIterator) Response() *Response { return it.resp }
+
+// Err returns the error that stopped the iteration, if any.
+func (it *VolumesIterator) Err() error { return it.err }
@@ -1,18 +1,23 @@
 package books
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestVolumesList(t *testing.T) {
 	setup()
 	defer teardown()
 
-	volumeID := "1"
-	url := fmt.Sprintf("/mylibrary/bookshelves/%s/volumes", volumeID)
+	shelf := ShelfPurchased
+	url := fmt.Sprintf("/mylibrary/bookshelves/%d/volumes", shelf)
 	mux.HandleFunc(url, func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "GET")
 		fmt.Fprint(w, `{"totalItems":1,"items":[{"id":"VN2jCgAAAEAJ","volumeInfo":{"title":"Go in Action","contentVersion":"full-1.0.0"}}]}`)
@@ -23,7 +28,7 @@ func TestVolumesList(t *testing.T) {
 		MaxResults: 1,
 	}
 
-	list, _, err := client.Volumes.List(volumeID, opts)
+	list, _, err := client.Volumes.List(shelf, opts)
 	if err != nil {
 		t.Errorf("List() returned an error: %v", err)
 	}
@@ -41,7 +46,7 @@ func TestVolumesList_badBody(t *testing.T) {
 	defer teardown()
 
 	opts := &VolumesListOptions{}
-	_, resp, err := client.Volumes.List("1", opts)
+	_, resp, err := client.Volumes.List(ShelfPurchased, opts)
 
 	// Check that response is error on nil request body
 	if err == nil {
@@ -59,7 +64,7 @@ func TestVolumesList_emptyVolume(t *testing.T) {
 	defer teardown()
 
 	opts := &VolumesListOptions{}
-	_, _, err := client.Volumes.List("", opts)
+	_, _, err := client.Volumes.List(ShelfID(-1), opts)
 
 	// Check that response is error on nil request body
 	if err == nil {
@@ -67,3 +72,377 @@ func TestVolumesList_emptyVolume(t *testing.T) {
 	}
 
 }
+
+func TestVolumesSearch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/volumes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"q":          `golang intitle:"go in action" inauthor:kennedy`,
+			"filter":     "ebooks",
+			"printType":  "books",
+			"orderBy":    "newest",
+			"startIndex": "10",
+			"maxResults": "1",
+		})
+		fmt.Fprint(w, `{"totalItems":1,"items":[{"id":"HnqJCgAAQBAJ","volumeInfo":{"title":"Go in Action"}}]}`)
+	})
+
+	opts := &VolumesSearchOptions{
+		Query:      &VolumesQuery{Terms: "golang", InTitle: "go in action", InAuthor: "kennedy"},
+		Filter:     FilterEbooks,
+		PrintType:  PrintTypeBooks,
+		OrderBy:    OrderByNewest,
+		StartIndex: 10,
+		MaxResults: 1,
+	}
+
+	list, _, err := client.Volumes.Search(opts)
+	if err != nil {
+		t.Errorf("Search() returned an error: %v", err)
+	}
+
+	expected := []Volume{{ID: String("HnqJCgAAQBAJ"), Info: &VolumeInfo{Title: String("Go in Action")}}}
+
+	if !reflect.DeepEqual(list, expected) {
+		t.Errorf("Search() returned %+v, expected %+v", list, expected)
+	}
+}
+
+func TestVolumesSearch_emptyQuery(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []*VolumesSearchOptions{nil, {}, {Query: &VolumesQuery{}}}
+
+	for _, opts := range cases {
+		if _, _, err := client.Volumes.Search(opts); err == nil {
+			t.Errorf("Search(%+v) expected query error", opts)
+		}
+	}
+}
+
+func TestVolumesQuery_String(t *testing.T) {
+	cases := []struct {
+		query    VolumesQuery
+		expected string
+	}{
+		{VolumesQuery{Terms: "flowers", InAuthor: "keyes"}, "flowers inauthor:keyes"},
+		{VolumesQuery{ISBN: "9781617291784"}, "isbn:9781617291784"},
+		{VolumesQuery{InPublisher: "Manning", Subject: "computers", LCCN: "2015474433", OCLC: "911189567"}, "inpublisher:Manning subject:computers lccn:2015474433 oclc:911189567"},
+		{VolumesQuery{InTitle: "the go programming language"}, `intitle:"the go programming language"`},
+		{VolumesQuery{}, ""},
+	}
+
+	for _, c := range cases {
+		if got := c.query.String(); got != c.expected {
+			t.Errorf("String() = %q; expected %q", got, c.expected)
+		}
+	}
+}
+
+func TestVolumesGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/volumes/VN2jCgAAAEAJ", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"projection":             "lite",
+			"country":                "US",
+			"includeNonComicsSeries": "true",
+		})
+		fmt.Fprint(w, `{"id":"VN2jCgAAAEAJ","volumeInfo":{"title":"Go in Action","contentVersion":"full-1.0.0"}}`)
+	})
+
+	opts := &VolumeGetOptions{
+		Projection:             ProjectionLite,
+		Country:                "US",
+		IncludeNonComicsSeries: true,
+	}
+
+	volume, _, err := client.Volumes.Get("VN2jCgAAAEAJ", opts)
+	if err != nil {
+		t.Errorf("Get() returned an error: %v", err)
+	}
+
+	expected := &Volume{ID: String("VN2jCgAAAEAJ"), Info: &VolumeInfo{Title: String("Go in Action"), ContentVersion: String("full-1.0.0")}}
+
+	if !reflect.DeepEqual(volume, expected) {
+		t.Errorf("Get() returned %+v, expected %+v", volume, expected)
+	}
+}
+
+func TestVolumesGet_emptyVolume(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, _, err := client.Volumes.Get("", nil)
+	if err == nil {
+		t.Error("Get() Expected volumeID error.")
+	}
+}
+
+func TestVolumesGet_golden(t *testing.T) {
+	setup()
+	defer teardown()
+
+	golden := loadFixture(t, "volumes_get.json")
+	mux.HandleFunc("/volumes/HnqJCgAAQBAJ", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(golden)
+	})
+
+	volume, _, err := client.Volumes.Get("HnqJCgAAQBAJ", nil)
+	if err != nil {
+		t.Fatalf("Get() returned an error: %v", err)
+	}
+
+	testJSONRoundTrip(t, golden, volume)
+
+	info := volume.Info
+	if got, expected := info.Authors, []string{"William Kennedy", "Brian Ketelsen", "Erik St. Martin"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Authors = %v, expected %v", got, expected)
+	}
+	expectedIDs := []IndustryIdentifier{
+		{Type: String("ISBN_10"), Identifier: String("1617291781")},
+		{Type: String("ISBN_13"), Identifier: String("9781617291784")},
+	}
+	if !reflect.DeepEqual(info.IndustryIdentifiers, expectedIDs) {
+		t.Errorf("IndustryIdentifiers = %+v, expected %+v", info.IndustryIdentifiers, expectedIDs)
+	}
+	if got, expected := *info.AverageRating, 4.5; got != expected {
+		t.Errorf("AverageRating = %v, expected %v", got, expected)
+	}
+	if got, expected := *info.Dimensions.Height, "23.50 cm"; got != expected {
+		t.Errorf("Dimensions.Height = %v, expected %v", got, expected)
+	}
+	if got, expected := *info.ImageLinks.ExtraLarge, "http://books.google.com/books/content?id=HnqJCgAAQBAJ&printsec=frontcover&img=1&zoom=6&source=gbs_api"; got != expected {
+		t.Errorf("ImageLinks.ExtraLarge = %v, expected %v", got, expected)
+	}
+
+	updated := time.Date(2016, 5, 13, 21, 39, 50, 456000000, time.UTC)
+	if got := volume.UserInfo.ReadingPosition.Updated; !got.Equal(updated) {
+		t.Errorf("UserInfo.ReadingPosition.Updated = %v, expected %v", got, updated)
+	}
+	if got, expected := *volume.UserInfo.Copy.RemainingCharacterCount, 78432; got != expected {
+		t.Errorf("UserInfo.Copy.RemainingCharacterCount = %v, expected %v", got, expected)
+	}
+	if got, expected := *volume.SaleInfo.RetailPrice.Amount, 28.79; got != expected {
+		t.Errorf("SaleInfo.RetailPrice.Amount = %v, expected %v", got, expected)
+	}
+	if !*volume.AccessInfo.Epub.IsAvailable || *volume.AccessInfo.Viewability != "PARTIAL" {
+		t.Errorf("AccessInfo = %+v, expected available epub with PARTIAL viewability", volume.AccessInfo)
+	}
+	if got, expected := *volume.AccessInfo.DownloadAccess.MaxDownloadDevices, 6; got != expected {
+		t.Errorf("AccessInfo.DownloadAccess.MaxDownloadDevices = %v, expected %v", got, expected)
+	}
+	expectedLayers := []VolumeLayer{{LayerID: String("notes"), VolumeAnnotationsVersion: String("12")}}
+	if !reflect.DeepEqual(volume.LayerInfo.Layers, expectedLayers) {
+		t.Errorf("LayerInfo.Layers = %+v, expected %+v", volume.LayerInfo.Layers, expectedLayers)
+	}
+}
+
+func TestVolumesSearch_golden(t *testing.T) {
+	setup()
+	defer teardown()
+
+	golden := loadFixture(t, "volumes_search.json")
+	mux.HandleFunc("/volumes", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(golden)
+	})
+
+	list, _, err := client.Volumes.Search(&VolumesSearchOptions{Query: &VolumesQuery{Terms: "go"}})
+	if err != nil {
+		t.Fatalf("Search() returned an error: %v", err)
+	}
+
+	var root struct {
+		Items json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(golden, &root); err != nil {
+		t.Fatalf("json.Unmarshal(): %v", err)
+	}
+	testJSONRoundTrip(t, root.Items, list)
+
+	if got, expected := *list[0].SearchInfo.TextSnippet, "Go in Action introduces the <b>Go</b> language."; got != expected {
+		t.Errorf("SearchInfo.TextSnippet = %q, expected %q", got, expected)
+	}
+}
+
+func TestVolumesListForUser(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/1127/bookshelves/0/volumes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"startIndex": "10", "maxResults": "5"})
+		fmt.Fprint(w, `{"totalItems":11,"items":[{"id":"VN2jCgAAAEAJ","volumeInfo":{"title":"Go in Action"}}]}`)
+	})
+
+	list, _, err := client.Volumes.ListForUser("1127", ShelfFavorites, &VolumesListOptions{StartIndex: 10, MaxResults: 5})
+	if err != nil {
+		t.Errorf("ListForUser() returned an error: %v", err)
+	}
+
+	expected := []Volume{{ID: String("VN2jCgAAAEAJ"), Info: &VolumeInfo{Title: String("Go in Action")}}}
+	if !reflect.DeepEqual(list, expected) {
+		t.Errorf("ListForUser() returned %+v, expected %+v", list, expected)
+	}
+
+	if _, _, err := client.Volumes.ListForUser("", ShelfFavorites, nil); err == nil {
+		t.Error("ListForUser() Expected userID error.")
+	}
+}
+
+func TestVolumesIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/mylibrary/bookshelves/1/volumes", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("startIndex") {
+		case "":
+			fmt.Fprint(w, `{"totalItems":3,"items":[{"id":"a"},{"id":"b"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"totalItems":3,"items":[{"id":"c"}]}`)
+		default:
+			t.Errorf("unexpected startIndex %q", r.URL.Query().Get("startIndex"))
+		}
+	})
+
+	it := NewVolumesIterator(context.Background(), client.Volumes, ShelfPurchased, &VolumesListOptions{MaxResults: 2}, 0)
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, *it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	if expected := []string{"a", "b", "c"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("iterated %v, expected %v", ids, expected)
+	}
+	if got := it.Response().TotalItems; got != 3 {
+		t.Errorf("Response().TotalItems = %v, expected 3", got)
+	}
+}
+
+func TestVolumesIterator_noTotalItems(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/mylibrary/bookshelves/1/volumes", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Query().Get("startIndex") {
+		case "":
+			fmt.Fprint(w, `{"items":[{"id":"a"},{"id":"b"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"items":[{"id":"c"}]}`)
+		default:
+			t.Errorf("unexpected startIndex %q", r.URL.Query().Get("startIndex"))
+		}
+	})
+
+	opts := &VolumesListOptions{MaxResults: 2, Fields: "items(id)"}
+	it := NewVolumesIterator(context.Background(), client.Volumes, ShelfPurchased, opts, 0)
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, *it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	if expected := []string{"a", "b", "c"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("iterated %v, expected %v", ids, expected)
+	}
+	if requests != 2 {
+		t.Errorf("made %d requests, expected 2", requests)
+	}
+}
+
+func TestVolumesSearchIterator_emptyPage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/volumes", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("startIndex") == "" {
+			// totalItems of search results is an estimate, the empty page is what ends the iteration.
+			fmt.Fprint(w, `{"totalItems":500,"items":[{"id":"a"},{"id":"b"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"totalItems":500}`)
+	})
+
+	opts := &VolumesSearchOptions{Query: &VolumesQuery{Terms: "go"}}
+	it := NewVolumesSearchIterator(context.Background(), client.Volumes, opts, 0)
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 2 || requests != 2 {
+		t.Errorf("iterated %d volumes in %d requests, expected 2 in 2", count, requests)
+	}
+}
+
+func TestVolumesSearchIterator_limit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var maxResults []string
+	mux.HandleFunc("/volumes", func(w http.ResponseWriter, r *http.Request) {
+		maxResults = append(maxResults, r.URL.Query().Get("maxResults"))
+		fmt.Fprint(w, `{"totalItems":500,"items":[{"id":"a"},{"id":"b"},{"id":"c"}]}`)
+	})
+
+	opts := &VolumesSearchOptions{Query: &VolumesQuery{Terms: "go"}, MaxResults: 40}
+	it := NewVolumesSearchIterator(context.Background(), client.Volumes, opts, 5)
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 5 {
+		t.Errorf("iterated %d volumes, expected 5", count)
+	}
+	if expected := []string{"5", "2"}; !reflect.DeepEqual(maxResults, expected) {
+		t.Errorf("maxResults = %v, expected %v", maxResults, expected)
+	}
+}
+
+func TestVolumesSearchIterator_pageSize(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var maxResults []string
+	mux.HandleFunc("/volumes", func(w http.ResponseWriter, r *http.Request) {
+		maxResults = append(maxResults, r.URL.Query().Get("maxResults"))
+		n, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+		items := make([]string, n)
+		for i := range items {
+			items[i] = `{"id":"v"}`
+		}
+		fmt.Fprintf(w, `{"totalItems":500,"items":[%s]}`, strings.Join(items, ","))
+	})
+
+	opts := &VolumesSearchOptions{Query: &VolumesQuery{Terms: "go"}}
+	it := NewVolumesSearchIterator(context.Background(), client.Volumes, opts, 100)
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 100 {
+		t.Errorf("iterated %d volumes, expected 100", count)
+	}
+	if expected := []string{"40", "40", "20"}; !reflect.DeepEqual(maxResults, expected) {
+		t.Errorf("maxResults = %v, expected %v", maxResults, expected)
+	}
+}